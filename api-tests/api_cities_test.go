@@ -0,0 +1,39 @@
+//go:build component
+
+package test
+
+import (
+	"io"
+	"net/http"
+	"testing"
+)
+
+func Test_GetApiCities_200(t *testing.T) {
+	r, err := HTTPClient.Get(baseURL + "/api/cities")
+	if err != nil {
+		t.Errorf("GET /api/cities error executing request = %v", err)
+		return
+	}
+
+	defer r.Body.Close()
+
+	if r.StatusCode != http.StatusOK {
+		t.Errorf("GET /api/cities HTTP status code = %v, want %v", r.StatusCode, http.StatusOK)
+	}
+
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		t.Errorf("GET /api/cities error reading body = %v", err)
+		return
+	}
+
+	want := `[{"name":"London","latitude":51.514248,"longitude":-0.093145,"distance":50,"unit":"mi","links":{"people":"/api/people/london"}}]` + "\n"
+
+	if string(b) != want {
+		t.Errorf("GET /api/cities response body %s, want %s", b, want)
+	}
+}
+
+func Test_GetApiCitiesCity_404(t *testing.T) {
+	test404(t, "/api/cities/atlantis", "City Not Found")
+}
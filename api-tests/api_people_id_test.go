@@ -0,0 +1,47 @@
+//go:build component
+
+package test
+
+import (
+	"io"
+	"net/http"
+	"testing"
+)
+
+func Test_GetApiPeopleID_200(t *testing.T) {
+	r, err := HTTPClient.Get(baseURL + "/api/people/1")
+	if err != nil {
+		t.Errorf("GET /api/people/{id} error executing request = %v", err)
+		return
+	}
+
+	defer r.Body.Close()
+
+	if r.StatusCode != http.StatusOK {
+		t.Errorf("GET /api/people/{id} HTTP status code = %v, want %v", r.StatusCode, http.StatusOK)
+	}
+
+	if r.Header.Get("Content-Type") != ContentTypeApplicationJSON {
+		t.Errorf("GET /api/people/{id} HTTP Content-Type = %v, want application/json", r.Header.Get("Content-Type"))
+	}
+
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		t.Errorf("GET /api/people/{id} error reading body = %v", err)
+		return
+	}
+
+	want := `{"ID":1,"first_name":"Maurise","last_name":"Shieldon","Email":"mshieldon0@squidoo.com","ip_address":"192.57.232.111","Latitude":34.003135,"Longitude":-117.7228641}` + "\n"
+
+	if string(b) != want {
+		t.Errorf("GET /api/people/{id} response body %s, want %s", b, want)
+	}
+}
+
+func Test_GetApiPeopleID_404(t *testing.T) {
+	test404(t, "/api/people/1001", "Person Not Found")
+}
+
+func Test_GetApiPersonsID_404(t *testing.T) {
+	test404(t, "/api/persons/1001", "Person Not Found")
+}
@@ -3,11 +3,13 @@
 package test
 
 import (
+	"io"
 	"net/http"
+	"strings"
 	"testing"
 )
 
-func Test_GetHealth_204(t *testing.T) {
+func Test_GetHealth_200(t *testing.T) {
 	r, err := HTTPClient.Get(baseURL + "/health")
 	if err != nil {
 		t.Errorf("GET /health error executing request = %v", err)
@@ -16,7 +18,19 @@ func Test_GetHealth_204(t *testing.T) {
 
 	defer r.Body.Close()
 
-	if r.StatusCode != http.StatusNoContent {
-		t.Errorf("GET /health HTTP status code = %v, want %v", r.StatusCode, http.StatusNoContent)
+	if r.StatusCode != http.StatusOK {
+		t.Errorf("GET /health HTTP status code = %v, want %v", r.StatusCode, http.StatusOK)
+	}
+
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		t.Errorf("GET /health error reading body = %v", err)
+		return
+	}
+
+	expectedBody := `{"status":"up","circuitBreaker":"closed"}`
+
+	if body := strings.TrimSpace(string(b)); body != expectedBody {
+		t.Errorf("GET /health response body %s, want %s", body, expectedBody)
 	}
 }
@@ -16,7 +16,7 @@ func main() {
 
 	r.Body.Close()
 
-	if r.StatusCode != http.StatusNoContent {
+	if r.StatusCode != http.StatusOK {
 		os.Exit(1)
 	}
 }
@@ -0,0 +1,99 @@
+// Command openapi generates the enums of configured cities in the OpenAPI specification from the configuration file,
+// so that the specification lists the same cities as the service. Each enum to generate is marked with a comment.
+//
+//	enum: # configured cities
+package main
+
+import (
+	"bytes"
+	"flag"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/J-R-Oliver/dwp-assessment-go/internal/configuration"
+)
+
+const marker = "enum: # configured cities"
+
+func main() {
+	configurationPath := flag.String("configuration", "./configuration.yaml", "path of the configuration file")
+	specificationPath := flag.String("specification", "./openapi-specification/openapi-specification.yml", "path of the OpenAPI specification")
+	check := flag.Bool("check", false, "fail if the specification is out of date rather than updating it")
+
+	flag.Parse()
+
+	c, err := configuration.LoadConfiguration(*configurationPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	info, err := os.Stat(*specificationPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	specification, err := os.ReadFile(*specificationPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	generated := generateCities(specification, cityNames(c))
+
+	if bytes.Equal(generated, specification) {
+		return
+	}
+
+	if *check {
+		log.Fatalf("fatal error: configured cities in %s are out of date - run go run ./cmd/openapi", *specificationPath)
+	}
+
+	if err = os.WriteFile(*specificationPath, generated, info.Mode().Perm()); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// cityNames returns the names and aliases of the configured cities as they appear in paths, in lower case and sorted.
+func cityNames(c configuration.Configuration) []string {
+	names := make([]string, 0, len(c.Cities))
+
+	for name, city := range c.Cities {
+		names = append(names, strings.ToLower(name))
+
+		for _, alias := range city.Aliases {
+			names = append(names, strings.ToLower(alias))
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// generateCities replaces the items of every marked enum in the specification with the cities.
+func generateCities(specification []byte, cities []string) []byte {
+	var generated bytes.Buffer
+
+	lines := strings.SplitAfter(string(specification), "\n")
+
+	for i := 0; i < len(lines); i++ {
+		generated.WriteString(lines[i])
+
+		if strings.TrimSpace(lines[i]) != marker {
+			continue
+		}
+
+		item := lines[i][:strings.Index(lines[i], marker)] + "  - "
+
+		for i+1 < len(lines) && strings.HasPrefix(lines[i+1], item) {
+			i++
+		}
+
+		for _, city := range cities {
+			generated.WriteString(item + city + "\n")
+		}
+	}
+
+	return generated.Bytes()
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"testing"
+)
+
+func Test_generateCities(t *testing.T) {
+	specification := `    city:
+      schema:
+        type: string
+        enum: # configured cities
+          - london
+      required: true
+    unit:
+      schema:
+        enum:
+          - mi
+`
+
+	want := `    city:
+      schema:
+        type: string
+        enum: # configured cities
+          - london
+          - manchester
+      required: true
+    unit:
+      schema:
+        enum:
+          - mi
+`
+
+	if got := string(generateCities([]byte(specification), []string{"london", "manchester"})); got != want {
+		t.Errorf("generateCities() = %s, want %s", got, want)
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net/http"
 	"os"
@@ -27,46 +28,136 @@ func main() {
 		log.Fatal(err)
 	}
 
-	l := logging.New(c.LoggingLevel)
+	l := logging.New(c.LoggingLevel, append(convertLoggingOutputs(c), logging.WithFormat(c.LoggingFormat))...)
+	l.Levels().NotifySignals(context.Background(), c.LoggingLevel)
 
 	cities := convertCities(c)
 
-	client := dwp.NewClient(c.PeopleConfiguration.BaseURL, http.Client{})
+	client := dwp.NewClient(c.PeopleConfiguration.BaseURL, http.Client{}, dwp.WithRetryPolicy(convertRetryPolicy(c)))
+
+	breaker := dwp.NewCircuitBreaker(client, dwp.BreakerSettings{
+		FailureThreshold: c.PeopleConfiguration.CircuitBreaker.FailureThreshold,
+		CoolDown:         c.PeopleConfiguration.CircuitBreaker.CoolDown,
+		HalfOpenRequests: c.PeopleConfiguration.CircuitBreaker.HalfOpenRequests,
+	})
+
+	coalescer := dwp.NewCoalescer(breaker)
+
+	cache := dwp.NewCache(coalescer, dwp.CacheSettings{
+		PeopleTTL:            c.PeopleConfiguration.Cache.PeopleTTL,
+		PeopleByCityTTL:      c.PeopleConfiguration.Cache.PeopleByCityTTL,
+		PersonTTL:            c.PeopleConfiguration.Cache.PersonTTL,
+		StaleWhileRevalidate: c.PeopleConfiguration.Cache.StaleWhileRevalidate,
+		StaleIfError:         c.PeopleConfiguration.Cache.StaleIfError,
+		MaxEntries:           c.PeopleConfiguration.Cache.MaxEntries,
+		MaxBytes:             c.PeopleConfiguration.Cache.MaxBytes,
+	})
 
 	s := people.Service{
-		DwpClient: client,
+		DwpClient: cache,
 		Cities:    cities,
-		Logger:    l,
+		Logger:    l.Component("people"),
 	}
 
 	h := handler.Handlers{
 		Service:         s,
 		DefaultDistance: c.PeopleConfiguration.Distance,
-		Cities:          c.Cities,
-		Logger:          l,
+		DefaultUnit:     convertUnit(c),
+		MinDistance:     c.PeopleConfiguration.MinDistance,
+		MaxDistance:     c.PeopleConfiguration.MaxDistance,
+		DefaultPageSize: c.PeopleConfiguration.PageSize,
+		MaxPageSize:     c.PeopleConfiguration.MaxPageSize,
+		CSVColumns:      convertCSVColumns(c),
+		Cities:          convertCityDefaults(c, cities),
+		CircuitBreaker:  breaker,
+		Logger:          l.Component("handler"),
 	}
 
 	serveMux := http.NewServeMux()
 
 	serveMux.HandleFunc("/api/people", h.GetPeople)
+	serveMux.HandleFunc("/api/people/near", h.GetPeopleNear)
 	serveMux.HandleFunc("/api/people/", h.GetPeopleByCity("/api/people/"))
+	serveMux.HandleFunc("/api/persons/", h.GetPerson("/api/persons/"))
+	serveMux.HandleFunc("/api/cities", h.GetCities)
+	serveMux.HandleFunc("/api/cities/", h.GetCity("/api/cities/"))
 	serveMux.HandleFunc("/health", h.Health)
 	serveMux.HandleFunc("/", h.NotFound)
 
-	middlewareChain := middleware.PanicHandler(serveMux, h.InternalServerError)
-	middlewareChain = middleware.LogRequestHandler(middlewareChain, l)
+	accessLogSettings := middleware.AccessLogSettings{
+		Format:    convertAccessLogFormat(c),
+		SkipPaths: c.AccessLog.SkipPaths,
+	}
+
+	middlewareChain := func(next http.Handler) http.Handler {
+		next = middleware.PanicHandler(next, h.InternalServerError)
+		next = middleware.AccessLogHandler(next, l.Component("access"), accessLogSettings)
+
+		return middleware.RequestIDHandler(next, l.Component("middleware"))
+	}
+
+	if c.Admin.Enabled {
+		adminMux := http.NewServeMux()
+
+		adminMux.HandleFunc("/admin/log-level", h.LogLevel)
+		adminMux.HandleFunc("/", h.NotFound)
+
+		adminSrv := &http.Server{
+			Addr:              c.Admin.Address,
+			Handler:           middlewareChain(adminMux),
+			ReadHeaderTimeout: time.Minute,
+		}
+
+		l.Infof("Starting admin server on %s", c.Admin.Address)
+
+		go func() {
+			if err := adminSrv.ListenAndServe(); err != nil {
+				l.Fatal(err)
+			}
+		}()
+	}
 
 	srv := &http.Server{
 		Addr:              ":" + c.Port,
-		Handler:           middlewareChain,
+		Handler:           middlewareChain(serveMux),
 		ReadHeaderTimeout: time.Minute,
 	}
 
-	l.Info("Starting server on :" + c.Port)
+	l.Infof("Starting server on :%s", c.Port)
 
 	if err := srv.ListenAndServe(); err != nil {
-		log.Fatal(err)
+		l.Fatal(err)
+	}
+}
+
+// logFileMode is the mode of log files created by the service.
+const logFileMode = 0o644
+
+func convertLoggingOutputs(c configuration.Configuration) []logging.Option {
+	options := make([]logging.Option, 0, len(c.LoggingOutputs))
+
+	for levelName, output := range c.LoggingOutputs {
+		level, err := logging.ParseLevel(levelName)
+		if err != nil {
+			log.Fatalf("fatal error: unable to parse logging output level: %s", err)
+		}
+
+		switch output {
+		case "stdout":
+			options = append(options, logging.WithWriter(level, os.Stdout))
+		case "stderr":
+			options = append(options, logging.WithWriter(level, os.Stderr))
+		default:
+			f, err := os.OpenFile(output, os.O_APPEND|os.O_CREATE|os.O_WRONLY, logFileMode)
+			if err != nil {
+				log.Fatalf("fatal error: unable to open %s log file %s: %s", level, output, err)
+			}
+
+			options = append(options, logging.WithWriter(level, f))
+		}
 	}
+
+	return options
 }
 
 func convertCities(c configuration.Configuration) map[string]haversine.Coord {
@@ -92,3 +183,82 @@ func convertCities(c configuration.Configuration) map[string]haversine.Coord {
 
 	return cities
 }
+
+func convertCityDefaults(c configuration.Configuration, coordinates map[string]haversine.Coord) map[string]handler.City {
+	cities := make(map[string]handler.City)
+
+	for cityName, city := range c.Cities {
+		cities[cityName] = handler.City{
+			Coordinates:     coordinates[cityName],
+			DefaultDistance: city.Distance,
+			Aliases:         city.Aliases,
+		}
+	}
+
+	if err := handler.CheckCities(cities); err != nil {
+		log.Fatalf("fatal error: unable to configure cities: %s", err)
+	}
+
+	return cities
+}
+
+// convertUnit returns the default distance unit, which is miles if none is configured.
+func convertUnit(c configuration.Configuration) people.Unit {
+	if c.PeopleConfiguration.DistanceUnit == "" {
+		return people.UnitMiles
+	}
+
+	unit, err := people.ParseUnit(c.PeopleConfiguration.DistanceUnit)
+	if err != nil {
+		log.Fatalf("fatal error: unable to parse default distance unit: %s", err)
+	}
+
+	return unit
+}
+
+// convertAccessLogFormat returns the access log format, which is the Combined Log Format if none is configured.
+func convertAccessLogFormat(c configuration.Configuration) middleware.AccessLogFormat {
+	if c.AccessLog.Format == "" {
+		return middleware.AccessLogCombined
+	}
+
+	format, err := middleware.ParseAccessLogFormat(c.AccessLog.Format)
+	if err != nil {
+		log.Fatalf("fatal error: unable to parse access log format: %s", err)
+	}
+
+	return format
+}
+
+func convertCSVColumns(c configuration.Configuration) []string {
+	columns, err := handler.ParseCSVColumns(c.PeopleConfiguration.CSVColumns)
+	if err != nil {
+		log.Fatalf("fatal error: unable to parse CSV columns: %s", err)
+	}
+
+	return columns
+}
+
+func convertRetryPolicy(c configuration.Configuration) dwp.RetryPolicy {
+	r := c.PeopleConfiguration.Retry
+
+	errorClasses := make([]dwp.ErrorClass, 0, len(r.Errors))
+
+	for _, e := range r.Errors {
+		errorClass := dwp.ErrorClass(e)
+		if !errorClass.Valid() {
+			log.Fatalf("fatal error: %s is not a valid retryable error class", e)
+		}
+
+		errorClasses = append(errorClasses, errorClass)
+	}
+
+	return dwp.RetryPolicy{
+		MaxAttempts:          r.MaxAttempts,
+		BaseDelay:            r.BaseDelay,
+		MaxDelay:             r.MaxDelay,
+		Jitter:               r.Jitter,
+		RetryableStatusCodes: r.StatusCodes,
+		RetryableErrors:      errorClasses,
+	}
+}
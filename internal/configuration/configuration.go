@@ -6,25 +6,80 @@ import (
 	"os"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/J-R-Oliver/dwp-assessment-go/pkg/logging"
 	"gopkg.in/yaml.v3"
 )
 
+type retryConfiguration struct {
+	MaxAttempts int           `yaml:"max-attempts"`
+	BaseDelay   time.Duration `yaml:"base-delay"`
+	MaxDelay    time.Duration `yaml:"max-delay"`
+	Jitter      float64       `yaml:"jitter"`
+	StatusCodes []int         `yaml:"status-codes"`
+	Errors      []string      `yaml:"errors"`
+}
+
+type circuitBreakerConfiguration struct {
+	FailureThreshold int           `yaml:"failure-threshold"`
+	CoolDown         time.Duration `yaml:"cool-down"`
+	HalfOpenRequests int           `yaml:"half-open-requests"`
+}
+
+type cacheConfiguration struct {
+	PeopleTTL            time.Duration `yaml:"people-ttl"`
+	PeopleByCityTTL      time.Duration `yaml:"people-by-city-ttl"`
+	PersonTTL            time.Duration `yaml:"person-ttl"`
+	StaleWhileRevalidate time.Duration `yaml:"stale-while-revalidate"`
+	StaleIfError         time.Duration `yaml:"stale-if-error"`
+	MaxEntries           int           `yaml:"max-entries"`
+	MaxBytes             int64         `yaml:"max-bytes"`
+}
+
 type peopleConfiguration struct {
-	BaseURL  string `yaml:"base-url"`
-	Distance int    `yaml:"default-distance"`
+	BaseURL        string                      `yaml:"base-url"`
+	Distance       int                         `yaml:"default-distance"`
+	DistanceUnit   string                      `yaml:"default-unit"`
+	MinDistance    int                         `yaml:"min-distance"`
+	MaxDistance    int                         `yaml:"max-distance"`
+	PageSize       int                         `yaml:"default-page-size"`
+	MaxPageSize    int                         `yaml:"max-page-size"`
+	CSVColumns     []string                    `yaml:"csv-columns"`
+	Retry          retryConfiguration          `yaml:"retry"`
+	CircuitBreaker circuitBreakerConfiguration `yaml:"circuit-breaker"`
+	Cache          cacheConfiguration          `yaml:"cache"`
 }
 
 type City struct {
 	Latitude  string `yaml:"lat"`
 	Longitude string `yaml:"lon"`
+	// Distance overrides the people default-distance for the city. Zero means the people default-distance is used.
+	Distance int `yaml:"default-distance"`
+	// Aliases are alternative names by which the city can be requested.
+	Aliases []string `yaml:"aliases"`
+}
+
+type accessLogConfiguration struct {
+	Format    string   `yaml:"format"`
+	SkipPaths []string `yaml:"skip-paths"`
+}
+
+type adminConfiguration struct {
+	Enabled bool `yaml:"enabled"`
+	// Address is the address the admin endpoints are served on, separately from the API.
+	Address string `yaml:"address"`
 }
 
 type Configuration struct {
-	Port                string              `yaml:"port"`
-	LoggingLevel        logging.Level       `yaml:"logging-level"`
-	PeopleConfiguration peopleConfiguration `yaml:"people"`
+	Port          string         `yaml:"port"`
+	LoggingLevel  logging.Level  `yaml:"logging-level"`
+	LoggingFormat logging.Format `yaml:"logging-format"`
+	// LoggingOutputs maps log levels to where their log entries are written - stdout, stderr or the path of a file.
+	LoggingOutputs      map[string]string      `yaml:"logging-outputs"`
+	AccessLog           accessLogConfiguration `yaml:"access-log"`
+	Admin               adminConfiguration     `yaml:"admin"`
+	PeopleConfiguration peopleConfiguration    `yaml:"people"`
 	Cities              map[string]City
 }
 
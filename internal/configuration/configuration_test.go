@@ -5,28 +5,77 @@ import (
 	"os"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/J-R-Oliver/dwp-assessment-go/pkg/logging"
 )
 
 func TestLoadConfiguration(t *testing.T) {
 	expected := Configuration{
-		Port:         "8080",
-		LoggingLevel: logging.Info,
+		Port:          "8080",
+		LoggingLevel:  logging.Info,
+		LoggingFormat: logging.FormatJSON,
+		LoggingOutputs: map[string]string{
+			"error": "stderr",
+			"info":  "stdout",
+			"debug": "/var/log/dwp-assessment-go/debug.log",
+		},
+		AccessLog: accessLogConfiguration{
+			Format:    "combined",
+			SkipPaths: []string{"/health"},
+		},
+		Admin: adminConfiguration{
+			Enabled: true,
+			Address: "127.0.0.1:8081",
+		},
 		PeopleConfiguration: peopleConfiguration{
-			BaseURL:  "https://dwp-techtest.herokuapp.com",
-			Distance: 50,
+			BaseURL:      "https://dwp-techtest.herokuapp.com",
+			Distance:     50,
+			DistanceUnit: "mi",
+			MinDistance:  1,
+			MaxDistance:  100,
+			PageSize:     1000,
+			MaxPageSize:  1000,
+			CSVColumns:   []string{"ID", "first_name", "last_name", "Email", "ip_address", "Latitude", "Longitude", "match", "distance"},
+			Retry: retryConfiguration{
+				MaxAttempts: 3,
+				BaseDelay:   100 * time.Millisecond,
+				MaxDelay:    2 * time.Second,
+				Jitter:      0.5,
+				StatusCodes: []int{500, 503},
+				Errors:      []string{"timeout"},
+			},
+			CircuitBreaker: circuitBreakerConfiguration{
+				FailureThreshold: 5,
+				CoolDown:         30 * time.Second,
+				HalfOpenRequests: 1,
+			},
+			Cache: cacheConfiguration{
+				PeopleTTL:            5 * time.Minute,
+				PeopleByCityTTL:      5 * time.Minute,
+				PersonTTL:            5 * time.Minute,
+				StaleWhileRevalidate: time.Minute,
+				StaleIfError:         time.Hour,
+				MaxEntries:           100,
+				MaxBytes:             10485760,
+			},
 		},
 		Cities: map[string]City{
 			"London": {
 				Latitude:  "51.514248",
 				Longitude: "-0.093145",
+				Distance:  25,
+				Aliases:   []string{"LDN"},
 			},
 		},
 	}
 
 	os.Setenv("PORT", "8080")
 	os.Setenv("LOGGING_LEVEL", "info")
+	os.Setenv("LOGGING_FORMAT", "json")
+	os.Setenv("ACCESS_LOG_FORMAT", "combined")
+	os.Setenv("ADMIN_ENABLED", "true")
+	os.Setenv("ADMIN_ADDRESS", "127.0.0.1:8081")
 	os.Setenv("PEOPLE_ENDPOINT", "https://dwp-techtest.herokuapp.com")
 
 	tests := []struct {
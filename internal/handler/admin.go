@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/J-R-Oliver/dwp-assessment-go/pkg/logging"
+)
+
+// logLevelMethods are the methods allowed by the log level endpoint.
+var logLevelMethods = []string{http.MethodGet, http.MethodPut, http.MethodDelete}
+
+// maxLogLevelRequestBytes is the largest request body accepted by the log level endpoint.
+const maxLogLevelRequestBytes = 1 << 10
+
+type logLevelRequest struct {
+	Level string `json:"level"`
+	// Component is the component whose level is changed. If empty, the level of every component without a level of
+	// its own is changed.
+	Component string `json:"component"`
+}
+
+type logLevelResponse struct {
+	Level      string            `json:"level"`
+	Components map[string]string `json:"components"`
+}
+
+// LogLevel returns the log levels of the running service, changes a level with a PUT request, or removes the level of
+// a component with a DELETE request whose component query names it. Changes take effect immediately and are logged.
+func (h Handlers) LogLevel(w http.ResponseWriter, r *http.Request) {
+	levels := h.Logger.Levels()
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		var request logLevelRequest
+
+		body := http.MaxBytesReader(w, r.Body, maxLogLevelRequestBytes)
+
+		if err := json.NewDecoder(body).Decode(&request); err != nil {
+			h.badRequest(w, r, "Invalid Request Body")
+			return
+		}
+
+		level, err := logging.ParseLevel(request.Level)
+		if err != nil {
+			h.badRequest(w, r, err.Error())
+			return
+		}
+
+		if request.Component == "" {
+			levels.SetLevel(level)
+		} else {
+			levels.SetComponentLevel(request.Component, level)
+		}
+	case http.MethodDelete:
+		component := r.URL.Query().Get("component")
+		if component == "" {
+			h.badRequest(w, r, "component is required")
+			return
+		}
+
+		levels.ResetComponentLevel(component)
+	default:
+		w.Header().Set("Allow", strings.Join(logLevelMethods, ", "))
+		h.errorHandler(w, r, http.StatusMethodNotAllowed, "Method Not Allowed")
+
+		return
+	}
+
+	response := logLevelResponse{Level: levels.Level().String(), Components: make(map[string]string)}
+
+	for component, level := range levels.Components() {
+		response.Components[component] = level.String()
+	}
+
+	w.Header().Set("Content-Type", ContentTypeApplicationJSON)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.InternalServerError(w, r, err)
+	}
+}
@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/J-R-Oliver/dwp-assessment-go/pkg/logging"
+)
+
+func TestHandlers_LogLevel(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			"Given a GET request then the levels are returned",
+			http.MethodGet, "/admin/log-level", "",
+			http.StatusOK, `{"level":"info","components":{}}` + "\n",
+		},
+		{
+			"Given a PUT request with a level then the level is changed",
+			http.MethodPut, "/admin/log-level", `{"level":"debug"}`,
+			http.StatusOK, `{"level":"debug","components":{}}` + "\n",
+		},
+		{
+			"Given a PUT request with a level and component then the level of the component is changed",
+			http.MethodPut, "/admin/log-level", `{"level":"error","component":"people"}`,
+			http.StatusOK, `{"level":"info","components":{"people":"error"}}` + "\n",
+		},
+		{
+			"Given a PUT request with an invalid level then bad request response",
+			http.MethodPut, "/admin/log-level", `{"level":"verbose"}`,
+			http.StatusBadRequest, `"message":"verbose is not a valid log level - valid options are fatal, error, warn, info, debug or trace"`,
+		},
+		{
+			"Given a PUT request with an invalid body then bad request response",
+			http.MethodPut, "/admin/log-level", `level=debug`,
+			http.StatusBadRequest, `"message":"Invalid Request Body"`,
+		},
+		{
+			"Given a PUT request with a body that is too large then bad request response",
+			http.MethodPut, "/admin/log-level", `{"level":"debug","component":"` + strings.Repeat("a", 2048) + `"}`,
+			http.StatusBadRequest, `"message":"Invalid Request Body"`,
+		},
+		{
+			"Given a DELETE request with a component then the level of the component is removed",
+			http.MethodDelete, "/admin/log-level?component=handler", "",
+			http.StatusOK, `{"level":"info","components":{}}` + "\n",
+		},
+		{
+			"Given a DELETE request without a component then bad request response",
+			http.MethodDelete, "/admin/log-level", "",
+			http.StatusBadRequest, `"message":"component is required"`,
+		},
+		{
+			"Given a POST request then method not allowed response",
+			http.MethodPost, "/admin/log-level", "",
+			http.StatusMethodNotAllowed, `"message":"Method Not Allowed"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := Handlers{Logger: logging.New(logging.Info).Component("handler")}
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+
+			h.LogLevel(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("LogLevel() = %v, want %v", w.Code, tt.wantStatus)
+			}
+
+			if body := w.Body.String(); !strings.Contains(body, tt.wantBody) {
+				t.Errorf("LogLevel() = %v, want %v", body, tt.wantBody)
+			}
+		})
+	}
+
+	t.Run("Given a POST request then the allowed methods are returned", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/admin/log-level", nil)
+
+		Handlers{Logger: logging.New(logging.Info)}.LogLevel(w, r)
+
+		if got := w.Header().Get("Allow"); got != "GET, PUT, DELETE" {
+			t.Errorf("LogLevel() Allow = %v, want GET, PUT, DELETE", got)
+		}
+	})
+}
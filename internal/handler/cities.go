@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/J-R-Oliver/dwp-assessment-go/pkg/logging"
+	"github.com/umahmood/haversine"
+)
+
+// peoplePath is the path of the endpoint that retrieves people by city, which is linked to from each city.
+const peoplePath = "/api/people/"
+
+// City is a city whose people can be retrieved.
+type City struct {
+	Coordinates haversine.Coord
+	// DefaultDistance overrides Handlers.DefaultDistance for the city. Zero means Handlers.DefaultDistance is used.
+	DefaultDistance int
+	// Aliases are alternative names by which the city can be requested, such as LDN for London.
+	Aliases []string
+}
+
+type cityResponse struct {
+	Name      string            `json:"name"`
+	Latitude  float64           `json:"latitude"`
+	Longitude float64           `json:"longitude"`
+	Distance  int               `json:"distance"`
+	Unit      string            `json:"unit"`
+	Links     map[string]string `json:"links"`
+}
+
+// GetCities returns every configured city, ordered by name.
+func (h Handlers) GetCities(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.methodNotAllow(w, r)
+		return
+	}
+
+	names := make([]string, 0, len(h.Cities))
+
+	for name := range h.Cities {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	cities := make([]cityResponse, 0, len(names))
+
+	for _, name := range names {
+		cities = append(cities, h.newCityResponse(name, h.Cities[name]))
+	}
+
+	h.writeCities(w, r, cities)
+}
+
+// GetCity returns the city named by the path after pathPrefix.
+func (h Handlers) GetCity(pathPrefix string) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			h.methodNotAllow(w, r)
+			return
+		}
+
+		path := pathSegment(r, pathPrefix)
+
+		name, city, ok := h.city(path)
+		if !ok {
+			h.logger(r).With(logging.Fields{"city": path}).Info("city not found")
+			h.notFound(w, r, "City Not Found")
+
+			return
+		}
+
+		h.writeCities(w, r, h.newCityResponse(name, city))
+	}
+}
+
+// city returns the configured name of the city with the name or alias, which are matched after normalisation.
+func (h Handlers) city(name string) (string, City, bool) {
+	normalised := normaliseCity(name)
+	if normalised == "" {
+		return "", City{}, false
+	}
+
+	for configured, city := range h.Cities {
+		if normaliseCity(configured) == normalised {
+			return configured, city, true
+		}
+
+		for _, alias := range city.Aliases {
+			if normaliseCity(alias) == normalised {
+				return configured, city, true
+			}
+		}
+	}
+
+	return "", City{}, false
+}
+
+// normaliseCity folds the case of a city name and treats runs of spaces, hyphens and underscores as a single space, so
+// that "New York", "new-york" and "NEW_YORK" are all normalised to "new york".
+func normaliseCity(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return unicode.IsSpace(r) || r == '-' || r == '_'
+	})
+
+	return strings.Join(words, " ")
+}
+
+// CheckCities returns an error if the names and aliases of the cities are not unique after normalisation, as requests
+// for them would be ambiguous.
+func CheckCities(cities map[string]City) error {
+	seen := make(map[string]string)
+
+	for configured, city := range cities {
+		for _, name := range append([]string{configured}, city.Aliases...) {
+			normalised := normaliseCity(name)
+
+			if normalised == "" {
+				return fmt.Errorf("city %s has an empty name or alias", configured)
+			}
+
+			if other, ok := seen[normalised]; ok && other != configured {
+				return fmt.Errorf("cities %s and %s are both named %s", other, configured, name)
+			}
+
+			seen[normalised] = configured
+		}
+	}
+
+	return nil
+}
+
+// pathSegment returns the path of the request after pathPrefix, without a trailing slash. The path has already been
+// URL decoded.
+func pathSegment(r *http.Request, pathPrefix string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, pathPrefix), "/")
+}
+
+// cityDistance returns the default distance of the city, in the default unit.
+func (h Handlers) cityDistance(city City) int {
+	if city.DefaultDistance != 0 {
+		return city.DefaultDistance
+	}
+
+	return h.DefaultDistance
+}
+
+func (h Handlers) newCityResponse(name string, city City) cityResponse {
+	return cityResponse{
+		Name:      name,
+		Latitude:  city.Coordinates.Lat,
+		Longitude: city.Coordinates.Lon,
+		Distance:  h.cityDistance(city),
+		Unit:      h.DefaultUnit.String(),
+		Links:     map[string]string{"people": peoplePath + url.PathEscape(strings.ToLower(name))},
+	}
+}
+
+func (h Handlers) writeCities(w http.ResponseWriter, r *http.Request, cities interface{}) {
+	w.Header().Set("Content-Type", ContentTypeApplicationJSON)
+
+	if err := json.NewEncoder(w).Encode(cities); err != nil {
+		h.InternalServerError(w, r, err)
+	}
+}
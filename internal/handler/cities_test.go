@@ -0,0 +1,230 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/J-R-Oliver/dwp-assessment-go/internal/people"
+	"github.com/J-R-Oliver/dwp-assessment-go/pkg/logging"
+	"github.com/umahmood/haversine"
+)
+
+func citiesHandlers() Handlers {
+	return Handlers{
+		DefaultDistance: 50,
+		DefaultUnit:     people.UnitMiles,
+		Cities: map[string]City{
+			london:       {Coordinates: haversine.Coord{Lat: 51.514248, Lon: -0.093145}},
+			"Manchester": {Coordinates: haversine.Coord{Lat: 53.4808, Lon: -2.2426}, DefaultDistance: 20},
+		},
+		Logger: logging.New(logging.Info),
+	}
+}
+
+func TestHandlers_GetCities(t *testing.T) {
+	t.Run("Given a request then every city is returned ordered by name", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/api/cities", nil)
+
+		citiesHandlers().GetCities(w, r)
+
+		resp := w.Result()
+
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("GetCities() = %v, want %v", resp.StatusCode, http.StatusOK)
+		}
+
+		b, _ := io.ReadAll(resp.Body)
+
+		want := `[{"name":"London","latitude":51.514248,"longitude":-0.093145,"distance":50,"unit":"mi","links":{"people":"/api/people/london"}},` +
+			`{"name":"Manchester","latitude":53.4808,"longitude":-2.2426,"distance":20,"unit":"mi","links":{"people":"/api/people/manchester"}}]` + "\n"
+
+		if string(b) != want {
+			t.Errorf("GetCities() = %s, want %s", b, want)
+		}
+	})
+
+	t.Run("Given a request with a method other than GET then method not allowed response", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/api/cities", nil)
+
+		citiesHandlers().GetCities(w, r)
+
+		if w.Code != http.StatusMethodNotAllowed {
+			t.Errorf("GetCities() = %v, want %v", w.Code, http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+func TestHandlers_GetCity(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			"Given a configured city then the city is returned",
+			"/api/cities/manchester",
+			http.StatusOK,
+			`{"name":"Manchester","latitude":53.4808,"longitude":-2.2426,"distance":20,"unit":"mi","links":{"people":"/api/people/manchester"}}` + "\n",
+		},
+		{
+			"Given a city that is not configured then not found response",
+			"/api/cities/atlantis",
+			http.StatusNotFound,
+			"",
+		},
+		{
+			"Given no city then not found response",
+			"/api/cities/",
+			http.StatusNotFound,
+			"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, tt.path, nil)
+
+			citiesHandlers().GetCity("/api/cities/")(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("GetCity() = %v, want %v", w.Code, tt.wantStatus)
+			}
+
+			if tt.wantBody != "" && w.Body.String() != tt.wantBody {
+				t.Errorf("GetCity() = %s, want %s", w.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestHandlers_GetPeopleByCity_cityDefaultDistance(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/api/people/manchester?unit=km", nil)
+
+	mockRetrievePeopleByCity = func(city string, q people.Query) (people.People, error) {
+		if city != "Manchester" || q.Distance != 32 {
+			t.Errorf("GetPeopleByCity() city = %v, distance = %v, want Manchester and 32", city, q.Distance)
+		}
+
+		return people.People{}, nil
+	}
+
+	h := citiesHandlers()
+	h.Service = mockService{}
+	h.GetPeopleByCity("/api/people/")(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("GetPeopleByCity() = %v, want %v", w.Code, http.StatusOK)
+	}
+}
+
+func TestHandlers_city(t *testing.T) {
+	h := Handlers{
+		Cities: map[string]City{
+			london:     {Aliases: []string{"LDN"}},
+			"New York": {Aliases: []string{"NYC", "Big Apple"}},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		want   string
+		wantOk bool
+	}{
+		{"London", london, true},
+		{"LONDON", london, true},
+		{"london", london, true},
+		{"ldn", london, true},
+		{"new york", "New York", true},
+		{"New-York", "New York", true},
+		{"new_york", "New York", true},
+		{" new  york ", "New York", true},
+		{"big-apple", "New York", true},
+		{"", "", false},
+		{"/", "", false},
+		{"atlantis", "", false},
+		{"london/extra", "", false},
+	}
+
+	for _, tt := range tests {
+		got, _, ok := h.city(tt.name)
+		if got != tt.want || ok != tt.wantOk {
+			t.Errorf("city(%q) = %v, %v, want %v, %v", tt.name, got, ok, tt.want, tt.wantOk)
+		}
+	}
+}
+
+func TestCheckCities(t *testing.T) {
+	tests := []struct {
+		name    string
+		cities  map[string]City
+		wantErr bool
+	}{
+		{"unique names and aliases", map[string]City{london: {Aliases: []string{"LDN"}}, "New York": {Aliases: []string{"NYC"}}}, false},
+		{"alias repeating the city name", map[string]City{london: {Aliases: []string{"LONDON"}}}, false},
+		{"alias of another city", map[string]City{london: {Aliases: []string{"NYC"}}, "New York": {Aliases: []string{"NYC"}}}, true},
+		{"names differing only by case", map[string]City{london: {}, "london": {}}, true},
+		{"empty alias", map[string]City{london: {Aliases: []string{" "}}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CheckCities(tt.cities); (err != nil) != tt.wantErr {
+				t.Errorf("CheckCities() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHandlers_GetPeopleByCity_cityPath(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+	}{
+		{"Given a city in upper case then people are returned", "/api/people/LONDON", http.StatusOK},
+		{"Given a city with a trailing slash then people are returned", "/api/people/london/", http.StatusOK},
+		{"Given a URL encoded city with spaces then people are returned", "/api/people/new%20york", http.StatusOK},
+		{"Given an alias then people are returned", "/api/people/nyc", http.StatusOK},
+		{"Given no city then not found response", "/api/people/", http.StatusNotFound},
+		{"Given a nested path then not found response", "/api/people/london/extra", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, tt.path, nil)
+
+			mockRetrievePeopleByCity = func(city string, q people.Query) (people.People, error) {
+				if city != london && city != "New York" {
+					t.Errorf("GetPeopleByCity() city = %v, want configured city name", city)
+				}
+
+				return people.People{}, nil
+			}
+
+			h := Handlers{
+				Service:         mockService{},
+				DefaultDistance: 50,
+				Cities: map[string]City{
+					london:     {},
+					"New York": {Aliases: []string{"NYC"}},
+				},
+				Logger: logging.New(logging.Info),
+			}
+			h.GetPeopleByCity("/api/people/")(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("GetPeopleByCity() = %v, want %v", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -1,10 +1,17 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 	"time"
+
+	"github.com/J-R-Oliver/dwp-assessment-go/pkg/dwp"
+	"github.com/J-R-Oliver/dwp-assessment-go/pkg/logging"
+	"github.com/J-R-Oliver/dwp-assessment-go/pkg/requestid"
 )
 
 type errorResponse struct {
@@ -12,23 +19,70 @@ type errorResponse struct {
 	Status    int       `json:"status"`
 	Message   string    `json:"message"`
 	Path      string    `json:"path"`
+	// RequestID identifies the request, as returned in the X-Request-ID header.
+	RequestID string `json:"requestId,omitempty"`
+	// Errors lists each invalid parameter of a bad request.
+	Errors []invalidParameter `json:"errors,omitempty"`
 }
 
 func (h Handlers) NotFound(w http.ResponseWriter, r *http.Request) {
-	h.Logger.Info(fmt.Sprintf("route not found - %s", r.URL.Path))
+	h.logger(r).With(logging.Fields{"path": r.URL.Path}).Info("route not found")
 	h.notFound(w, r, "Route Not Found")
 }
 
 func (h Handlers) InternalServerError(w http.ResponseWriter, r *http.Request, err error) {
-	h.Logger.Error(err)
+	h.logger(r).Error(err)
 
 	h.errorHandler(w, r, http.StatusInternalServerError, "Internal Server Error")
 }
 
+// serviceError maps errors returned by the service to an appropriate response. An open circuit breaker results in a
+// 503 with a Retry-After header, an upstream timeout in a 504, any other upstream failure in a 502, and all other
+// errors in a 500.
+func (h Handlers) serviceError(w http.ResponseWriter, r *http.Request, err error) {
+	var circuitOpenError *dwp.CircuitOpenError
+
+	var apiError *dwp.APIError
+
+	switch {
+	case errors.As(err, &circuitOpenError):
+		h.logger(r).Error(err)
+		h.serviceUnavailable(w, r, circuitOpenError.RetryAfter)
+	case errors.Is(err, dwp.ErrTimeout) || errors.Is(err, context.DeadlineExceeded):
+		h.logger(r).Error(err)
+		h.errorHandler(w, r, http.StatusGatewayTimeout, "Gateway Timeout")
+	case errors.As(err, &apiError) || errors.Is(err, dwp.ErrDecode):
+		h.logger(r).Error(err)
+		h.errorHandler(w, r, http.StatusBadGateway, "Bad Gateway")
+	default:
+		h.InternalServerError(w, r, err)
+	}
+}
+
+func (h Handlers) serviceUnavailable(w http.ResponseWriter, r *http.Request, retryAfter time.Duration) {
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	h.errorHandler(w, r, http.StatusServiceUnavailable, "Service Unavailable")
+}
+
 func (h Handlers) badRequest(w http.ResponseWriter, r *http.Request, message string) {
 	h.errorHandler(w, r, http.StatusBadRequest, message)
 }
 
+// invalidParameters responds with a bad request listing every invalid parameter.
+func (h Handlers) invalidParameters(w http.ResponseWriter, r *http.Request, invalid []invalidParameter) {
+	for _, p := range invalid {
+		h.logger(r).With(logging.Fields{"parameter": p.Parameter, "reason": p.Reason}).Info("bad query")
+	}
+
+	h.writeError(w, r, errorResponse{
+		Timestamp: time.Now(),
+		Status:    http.StatusBadRequest,
+		Message:   "Invalid Query Parameters",
+		Path:      r.URL.Path,
+		Errors:    invalid,
+	})
+}
+
 func (h Handlers) notFound(w http.ResponseWriter, r *http.Request, message string) {
 	h.errorHandler(w, r, http.StatusNotFound, message)
 }
@@ -39,20 +93,33 @@ func (h Handlers) methodNotAllow(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h Handlers) errorHandler(w http.ResponseWriter, r *http.Request, status int, message string) {
-	w.Header().Set("Content-Type", ContentTypeApplicationJSON)
-
-	w.WriteHeader(status)
-
-	response := errorResponse{
+	h.writeError(w, r, errorResponse{
 		Timestamp: time.Now(),
 		Status:    status,
 		Message:   message,
 		Path:      r.URL.Path,
+	})
+}
+
+// writeError writes the error response, or the equivalent problem details if they are preferred by the request.
+func (h Handlers) writeError(w http.ResponseWriter, r *http.Request, response errorResponse) {
+	response.RequestID, _ = requestid.FromContext(r.Context())
+
+	var body interface{} = response
+
+	if acceptsProblem(r) {
+		body = newProblemDetails(response)
+
+		w.Header().Set("Content-Type", ContentTypeApplicationProblemJSON)
+	} else {
+		w.Header().Set("Content-Type", ContentTypeApplicationJSON)
 	}
 
-	err := json.NewEncoder(w).Encode(response)
+	w.WriteHeader(response.Status)
+
+	err := json.NewEncoder(w).Encode(body)
 	if err != nil {
-		h.Logger.Error(err)
+		h.logger(r).Error(err)
 		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 	}
 }
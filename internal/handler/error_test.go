@@ -1,13 +1,19 @@
 package handler
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/J-R-Oliver/dwp-assessment-go/pkg/dwp"
 	"github.com/J-R-Oliver/dwp-assessment-go/pkg/logging"
+	"github.com/J-R-Oliver/dwp-assessment-go/pkg/requestid"
 )
 
 func TestHandlers_notFound(t *testing.T) {
@@ -45,6 +51,29 @@ func TestHandlers_notFound(t *testing.T) {
 	}
 }
 
+func TestHandlers_notFound_requestID(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/path", nil)
+	r = r.WithContext(requestid.NewContext(r.Context(), "a1b2c3"))
+
+	h := Handlers{Logger: logging.New(logging.Info)}
+
+	h.NotFound(w, r)
+
+	resp := w.Result()
+
+	defer resp.Body.Close()
+
+	b, _ := io.ReadAll(resp.Body)
+	body := string(b)
+
+	expectedBody := `"path":"/path","requestId":"a1b2c3"`
+
+	if !strings.Contains(body, expectedBody) {
+		t.Errorf("NotFound() = %v, want %v", body, expectedBody)
+	}
+}
+
 func TestHandlers_InternalServerError(t *testing.T) {
 	w := httptest.NewRecorder()
 	r := httptest.NewRequest(http.MethodGet, "/path", nil)
@@ -219,3 +248,77 @@ func TestHandlers_errorHandler(t *testing.T) {
 		t.Errorf("errorHandler() = %v, want %v", body, expectedBody)
 	}
 }
+
+func TestHandlers_serviceError(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		wantStatus     int
+		wantRetryAfter string
+	}{
+		{
+			"When error is a circuit open error then service unavailable response",
+			fmt.Errorf("wrapped: %w", &dwp.CircuitOpenError{RetryAfter: 10 * time.Second}),
+			http.StatusServiceUnavailable,
+			"10",
+		},
+		{
+			"When error is an upstream timeout then gateway timeout response",
+			fmt.Errorf("wrapped: %w", dwp.ErrTimeout),
+			http.StatusGatewayTimeout,
+			"",
+		},
+		{
+			"When error is a context deadline then gateway timeout response",
+			fmt.Errorf("wrapped: %w", context.DeadlineExceeded),
+			http.StatusGatewayTimeout,
+			"",
+		},
+		{
+			"When error is an APIError then bad gateway response",
+			fmt.Errorf("wrapped: %w", &dwp.APIError{StatusCode: http.StatusInternalServerError}),
+			http.StatusBadGateway,
+			"",
+		},
+		{
+			"When error is a decode error then bad gateway response",
+			fmt.Errorf("wrapped: %w", dwp.ErrDecode),
+			http.StatusBadGateway,
+			"",
+		},
+		{
+			"When error is unknown then internal server error response",
+			errors.New("test error"),
+			http.StatusInternalServerError,
+			"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/path", nil)
+
+			h := Handlers{
+				Service:         nil,
+				DefaultDistance: 0,
+				Cities:          nil,
+				Logger:          logging.New(logging.Info),
+			}
+
+			h.serviceError(w, r, tt.err)
+
+			resp := w.Result()
+
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("serviceError() = %v, want %v", resp.StatusCode, tt.wantStatus)
+			}
+
+			if resp.Header.Get("Retry-After") != tt.wantRetryAfter {
+				t.Errorf("serviceError() Retry-After = %v, want %v", resp.Header.Get("Retry-After"), tt.wantRetryAfter)
+			}
+		})
+	}
+}
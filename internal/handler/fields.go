@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// personFields are the keys of a serialised dwp.Person, in the order they are serialised.
+var personFields = []string{"ID", "first_name", "last_name", "Email", "ip_address", "Latitude", "Longitude"}
+
+// locatedPersonFields are the keys of a serialised people.Person, in the order they are serialised.
+var locatedPersonFields = append(personFields[:len(personFields):len(personFields)], "match", "distance")
+
+// parseFields validates the optional comma separated fields query against the known fields, which are matched case
+// insensitively. The selected fields are returned in the order of known, or nil if the query is absent.
+func parseFields(v *validator, query url.Values, known []string) []string {
+	if _, ok := query["fields"]; !ok {
+		return nil
+	}
+
+	canonical := make(map[string]string, len(known))
+
+	for _, field := range known {
+		canonical[strings.ToLower(field)] = field
+	}
+
+	requested := make(map[string]bool)
+
+	for _, field := range strings.Split(query.Get("fields"), ",") {
+		field = strings.TrimSpace(field)
+
+		c, ok := canonical[strings.ToLower(field)]
+		if !ok {
+			v.reject("fields", fmt.Sprintf("%s is not a known field - valid options are %s", field, strings.Join(known, ", ")))
+			continue
+		}
+
+		requested[c] = true
+	}
+
+	selected := make([]string, 0, len(requested))
+
+	for _, field := range known {
+		if requested[field] {
+			selected = append(selected, field)
+		}
+	}
+
+	return selected
+}
+
+// record is a serialised person, keyed by field.
+type record map[string]json.RawMessage
+
+// toRecords serialises people, a slice of dwp.Person or people.Person, into records.
+func toRecords(people interface{}) ([]record, error) {
+	b, err := json.Marshal(people)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []record
+
+	err = json.Unmarshal(b, &records)
+
+	return records, err
+}
+
+// toRecord serialises a dwp.Person or people.Person into a record.
+func toRecord(person interface{}) (record, error) {
+	b, err := json.Marshal(person)
+	if err != nil {
+		return nil, err
+	}
+
+	var rec record
+
+	err = json.Unmarshal(b, &rec)
+
+	return rec, err
+}
+
+// object returns the record as a JSON object holding only the fields present in the record, in the order given.
+func (rec record) object(fields []string) json.RawMessage {
+	var buf bytes.Buffer
+
+	buf.WriteByte('{')
+
+	for _, field := range fields {
+		value, ok := rec[field]
+		if !ok {
+			continue
+		}
+
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+
+		key, _ := json.Marshal(field)
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes()
+}
+
+// text returns the field of the record as plain text. Strings are unquoted, and absent or null fields are empty.
+func (rec record) text(field string) string {
+	value, ok := rec[field]
+	if !ok || string(value) == "null" {
+		return ""
+	}
+
+	var s string
+	if json.Unmarshal(value, &s) == nil {
+		return s
+	}
+
+	return string(value)
+}
@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/J-R-Oliver/dwp-assessment-go/internal/people"
+	"github.com/J-R-Oliver/dwp-assessment-go/pkg/dwp"
+)
+
+func Test_parseFields(t *testing.T) {
+	tests := []struct {
+		query   string
+		want    []string
+		invalid []invalidParameter
+	}{
+		{"", nil, nil},
+		{"fields=id,latitude,longitude", []string{"ID", "Latitude", "Longitude"}, nil},
+		{"fields=longitude, first_name,ID,first_name", []string{"ID", "first_name", "Longitude"}, nil},
+		{"fields=id,password", []string{"ID"}, []invalidParameter{{"fields", "password is not a known field - valid options are ID, first_name, last_name, Email, ip_address, Latitude, Longitude"}}},
+		{"fields=match", []string{}, []invalidParameter{{"fields", "match is not a known field - valid options are ID, first_name, last_name, Email, ip_address, Latitude, Longitude"}}},
+	}
+
+	for _, tt := range tests {
+		query, _ := url.ParseQuery(tt.query)
+		v := validator{}
+
+		if got := parseFields(&v, query, personFields); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseFields(%s) = %v, want %v", tt.query, got, tt.want)
+		}
+
+		if !reflect.DeepEqual(v.invalid, tt.invalid) {
+			t.Errorf("parseFields(%s) invalid = %v, want %v", tt.query, v.invalid, tt.invalid)
+		}
+	}
+}
+
+func Test_toRecords(t *testing.T) {
+	distance := 1.5
+	p := people.People{{Person: dwp.Person{ID: 1, FirstName: "Maurise", Email: "mshieldon0@squidoo.com"}, Match: people.MatchCity, Distance: &distance}}
+
+	records, err := toRecords(p)
+	if err != nil || len(records) != 1 {
+		t.Fatalf("toRecords() = %v, %v, want one record", records, err)
+	}
+
+	if got, want := string(records[0].object([]string{"ID", "first_name", "distance"})), `{"ID":1,"first_name":"Maurise","distance":1.5}`; got != want {
+		t.Errorf("object() = %s, want %s", got, want)
+	}
+
+	tests := []struct {
+		field string
+		want  string
+	}{
+		{"ID", "1"},
+		{"first_name", "Maurise"},
+		{"distance", "1.5"},
+		{"match", "city"},
+		{"unknown", ""},
+	}
+
+	for _, tt := range tests {
+		if got := records[0].text(tt.field); got != tt.want {
+			t.Errorf("text(%s) = %v, want %v", tt.field, got, tt.want)
+		}
+	}
+}
+
+func Test_personFields(t *testing.T) {
+	distance := 1.5
+
+	tests := []struct {
+		v     interface{}
+		known []string
+	}{
+		{dwp.Person{}, personFields},
+		{people.Person{Match: people.MatchCity, Distance: &distance}, locatedPersonFields},
+	}
+
+	for _, tt := range tests {
+		b, _ := json.Marshal(tt.v)
+
+		var values map[string]json.RawMessage
+
+		json.Unmarshal(b, &values) //nolint:errcheck
+
+		if len(values) != len(tt.known) {
+			t.Errorf("fields = %v, want %v", values, tt.known)
+		}
+
+		for _, field := range tt.known {
+			if _, ok := values[field]; !ok {
+				t.Errorf("field %s not serialised", field)
+			}
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+
+	"github.com/J-R-Oliver/dwp-assessment-go/internal/people"
+)
+
+// parseFilter validates the attribute filter queries shared by the people endpoints.
+func parseFilter(v *validator, query url.Values) people.Filter {
+	f := people.Filter{
+		FirstName:         query.Get("first_name"),
+		LastName:          query.Get("last_name"),
+		FirstNamePrefix:   query.Get("first_name_prefix"),
+		LastNamePrefix:    query.Get("last_name_prefix"),
+		FirstNameContains: query.Get("first_name_contains"),
+		LastNameContains:  query.Get("last_name_contains"),
+		EmailDomain:       query.Get("email_domain"),
+		MinID:             v.integer(query, "id_min", 0, 0, math.MaxInt),
+		MaxID:             v.integer(query, "id_max", 0, 1, math.MaxInt),
+	}
+
+	if ip := query.Get("ip"); ip != "" {
+		network, err := people.ParseIPNetwork(ip)
+		if err != nil {
+			v.reject("ip", fmt.Sprintf("%s is not an IP address or CIDR range", ip))
+		}
+
+		f.IPNetwork = network
+	}
+
+	if f.MaxID != 0 && f.MinID > f.MaxID {
+		v.reject("id_max", fmt.Sprintf("%d is less than id_min %d", f.MaxID, f.MinID))
+	}
+
+	return f
+}
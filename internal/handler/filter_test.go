@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/J-R-Oliver/dwp-assessment-go/internal/people"
+)
+
+func Test_parseFilter(t *testing.T) {
+	network, _ := people.ParseIPNetwork("10.0.0.0/8")
+
+	tests := []struct {
+		query   string
+		want    people.Filter
+		invalid []invalidParameter
+	}{
+		{"", people.Filter{}, nil},
+		{
+			"first_name=Bendix&last_name_prefix=Hal&first_name_contains=dix&email_domain=squidoo.com&ip=10.0.0.0/8&id_min=5&id_max=10",
+			people.Filter{FirstName: "Bendix", LastNamePrefix: "Hal", FirstNameContains: "dix", EmailDomain: "squidoo.com", IPNetwork: network, MinID: 5, MaxID: 10},
+			nil,
+		},
+		{"ip=10.0.0", people.Filter{}, []invalidParameter{{"ip", "10.0.0 is not an IP address or CIDR range"}}},
+		{"id_min=-1", people.Filter{}, []invalidParameter{{"id_min", "-1 is less than the minimum of 0"}}},
+		{"id_min=10&id_max=5", people.Filter{MinID: 10, MaxID: 5}, []invalidParameter{{"id_max", "5 is less than id_min 10"}}},
+	}
+
+	for _, tt := range tests {
+		query, _ := url.ParseQuery(tt.query)
+		v := validator{}
+
+		if got := parseFilter(&v, query); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseFilter(%s) = %v, want %v", tt.query, got, tt.want)
+		}
+
+		if !reflect.DeepEqual(v.invalid, tt.invalid) {
+			t.Errorf("parseFilter(%s) invalid = %v, want %v", tt.query, v.invalid, tt.invalid)
+		}
+	}
+}
@@ -0,0 +1,301 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/J-R-Oliver/dwp-assessment-go/pkg/dwp"
+)
+
+const (
+	ContentTypeTextCSV           = "text/csv"
+	ContentTypeApplicationNDJSON = "application/x-ndjson"
+)
+
+// format is a representation in which people can be returned.
+type format int
+
+const (
+	formatJSON format = iota
+	formatCSV
+	formatGeoJSON
+	formatNDJSON
+)
+
+// formats are the values of the format query, which overrides the Accept header.
+var formats = map[string]format{
+	"json":    formatJSON,
+	"csv":     formatCSV,
+	"geojson": formatGeoJSON,
+	"ndjson":  formatNDJSON,
+}
+
+// mediaTypes are the media types accepted in the Accept header.
+var mediaTypes = map[string]format{
+	ContentTypeApplicationJSON:    formatJSON,
+	ContentTypeTextCSV:            formatCSV,
+	ContentTypeApplicationGeoJSON: formatGeoJSON,
+	ContentTypeApplicationNDJSON:  formatNDJSON,
+}
+
+// negotiateFormat returns the format requested by the format query or, if it is absent, the acceptable media type with
+// the highest quality in the Accept header. JSON is returned if no supported media type is acceptable.
+func negotiateFormat(v *validator, r *http.Request, query url.Values) format {
+	if value := query.Get("format"); value != "" {
+		f, ok := formats[strings.ToLower(value)]
+		if !ok {
+			v.reject("format", fmt.Sprintf("%s is not a valid format - valid options are json, csv, geojson or ndjson", value))
+		}
+
+		return f
+	}
+
+	return negotiate(r, mediaTypes, formatJSON)
+}
+
+// negotiate returns the value of the acceptable media type with the highest quality in the Accept header of the
+// request, or fallback if none of the media types are acceptable.
+func negotiate[T any](r *http.Request, offers map[string]T, fallback T) T {
+	negotiated := fallback
+	quality := 0.0
+
+	for _, accept := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(accept))
+		if err != nil {
+			continue
+		}
+
+		offer, ok := offers[mediaType]
+		if !ok {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(value, bitSize); err != nil {
+				continue
+			}
+		}
+
+		if q > quality {
+			negotiated, quality = offer, q
+		}
+	}
+
+	return negotiated
+}
+
+// writePeople writes people, a slice of dwp.Person or people.Person, in the format. Only the selected fields are
+// written, or every known field if fields is nil. The searched area, if any, is only written as GeoJSON.
+func (h Handlers) writePeople(
+	w http.ResponseWriter,
+	r *http.Request,
+	people interface{},
+	known []string,
+	fields []string,
+	f format,
+	searched *area,
+) {
+	var err error
+
+	switch f {
+	case formatCSV:
+		err = h.writeCSV(w, people, known, fields)
+	case formatGeoJSON:
+		err = writeGeoJSON(w, people, known, fields, searched)
+	case formatNDJSON:
+		err = writeNDJSON(w, people, fields)
+	case formatJSON:
+		err = writeJSON(w, people, fields)
+	}
+
+	if err != nil {
+		h.InternalServerError(w, r, err)
+	}
+}
+
+// writePerson writes the person as JSON. Only the selected fields are written, or every field if fields is nil.
+func (h Handlers) writePerson(w http.ResponseWriter, r *http.Request, person dwp.Person, fields []string) {
+	var body interface{} = person
+
+	if fields != nil {
+		rec, err := toRecord(person)
+		if err != nil {
+			h.InternalServerError(w, r, err)
+			return
+		}
+
+		body = rec.object(fields)
+	}
+
+	w.Header().Set("Content-Type", ContentTypeApplicationJSON)
+
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		h.InternalServerError(w, r, err)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, people interface{}, fields []string) error {
+	w.Header().Set("Content-Type", ContentTypeApplicationJSON)
+
+	if fields == nil {
+		return json.NewEncoder(w).Encode(people)
+	}
+
+	records, err := toRecords(people)
+	if err != nil {
+		return err
+	}
+
+	objects := make([]json.RawMessage, 0, len(records))
+
+	for _, rec := range records {
+		objects = append(objects, rec.object(fields))
+	}
+
+	return json.NewEncoder(w).Encode(objects)
+}
+
+// writeNDJSON writes each person as a JSON object on its own line, flushing after every line so that the response body
+// is never buffered whole and clients can process people as they are written. The people themselves are already held
+// in memory.
+func writeNDJSON(w http.ResponseWriter, people interface{}, fields []string) error {
+	w.Header().Set("Content-Type", ContentTypeApplicationNDJSON)
+
+	flusher, _ := w.(http.Flusher)
+	encoder := json.NewEncoder(w)
+	items := reflect.ValueOf(people)
+
+	for i := 0; i < items.Len(); i++ {
+		person := items.Index(i).Interface()
+
+		if fields != nil {
+			rec, err := toRecord(person)
+			if err != nil {
+				return err
+			}
+
+			person = rec.object(fields)
+		}
+
+		if err := encoder.Encode(person); err != nil {
+			return err
+		}
+
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+
+	return nil
+}
+
+// writeCSV writes a header row followed by a row for each person. The columns are the configured CSV columns, or every
+// known field if none are configured, restricted to the selected fields.
+func (h Handlers) writeCSV(w http.ResponseWriter, people interface{}, known []string, fields []string) error {
+	records, err := toRecords(people)
+	if err != nil {
+		return err
+	}
+
+	columns := csvColumns(h.CSVColumns, known, fields)
+
+	w.Header().Set("Content-Type", ContentTypeTextCSV+"; charset=utf-8")
+
+	cw := csv.NewWriter(w)
+
+	if err = cw.Write(columns); err != nil {
+		return err
+	}
+
+	row := make([]string, len(columns))
+
+	for _, rec := range records {
+		for i, column := range columns {
+			row[i] = escapeFormula(rec.text(column))
+		}
+
+		if err = cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// formulaPrefixes are the characters which cause a spreadsheet to evaluate a cell as a formula.
+const formulaPrefixes = "=+-@\t\r"
+
+// escapeFormula prefixes a value with an apostrophe if a spreadsheet would otherwise evaluate it as a formula, so that
+// values from the People API cannot inject formulas into CSV responses. Numbers, such as negative coordinates, are not
+// escaped.
+func escapeFormula(s string) string {
+	if s == "" || !strings.ContainsRune(formulaPrefixes, rune(s[0])) {
+		return s
+	}
+
+	if _, err := strconv.ParseFloat(s, bitSize); err == nil {
+		return s
+	}
+
+	return "'" + s
+}
+
+func csvColumns(configured []string, known []string, fields []string) []string {
+	order := configured
+	if len(order) == 0 {
+		order = known
+	}
+
+	included := make(map[string]bool)
+
+	for _, field := range known {
+		included[field] = fields == nil
+	}
+
+	for _, field := range fields {
+		included[field] = true
+	}
+
+	columns := make([]string, 0, len(order))
+
+	for _, column := range order {
+		if included[column] {
+			columns = append(columns, column)
+		}
+	}
+
+	return columns
+}
+
+// ParseCSVColumns matches the configured CSV columns case insensitively against the fields of a person, returning
+// their canonical names.
+func ParseCSVColumns(columns []string) ([]string, error) {
+	canonical := make(map[string]string, len(locatedPersonFields))
+
+	for _, field := range locatedPersonFields {
+		canonical[strings.ToLower(field)] = field
+	}
+
+	parsed := make([]string, 0, len(columns))
+
+	for _, column := range columns {
+		c, ok := canonical[strings.ToLower(column)]
+		if !ok {
+			return nil, fmt.Errorf("%s is not a known field - valid options are %s", column, strings.Join(locatedPersonFields, ", "))
+		}
+
+		parsed = append(parsed, c)
+	}
+
+	return parsed, nil
+}
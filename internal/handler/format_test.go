@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/J-R-Oliver/dwp-assessment-go/internal/people"
+	"github.com/J-R-Oliver/dwp-assessment-go/pkg/dwp"
+)
+
+func Test_negotiateFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		accept  string
+		want    format
+		invalid bool
+	}{
+		{"no accept header", "", "", formatJSON, false},
+		{"any media type", "", "*/*", formatJSON, false},
+		{"json", "", "application/json", formatJSON, false},
+		{"csv", "", "text/csv", formatCSV, false},
+		{"csv with parameters", "", "text/csv; charset=utf-8", formatCSV, false},
+		{"geojson", "", "application/geo+json", formatGeoJSON, false},
+		{"ndjson", "", "application/x-ndjson", formatNDJSON, false},
+		{"preferred by quality", "", "application/json;q=0.5, text/csv;q=0.9", formatCSV, false},
+		{"unsupported media type", "", "application/xml", formatJSON, false},
+		{"format query overrides accept header", "format=csv", "application/json", formatCSV, false},
+		{"format query ignoring case", "format=JSON", "text/csv", formatJSON, false},
+		{"geojson format query", "format=geojson", "", formatGeoJSON, false},
+		{"invalid format query", "format=xml", "", formatJSON, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/api/people?"+tt.query, nil)
+			r.Header.Set("Accept", tt.accept)
+
+			query, _ := url.ParseQuery(tt.query)
+			v := validator{}
+
+			if got := negotiateFormat(&v, r, query); got != tt.want {
+				t.Errorf("negotiateFormat() = %v, want %v", got, tt.want)
+			}
+
+			if v.valid() == tt.invalid {
+				t.Errorf("negotiateFormat() invalid = %v, want %v", v.invalid, tt.invalid)
+			}
+		})
+	}
+}
+
+func Test_writeNDJSON(t *testing.T) {
+	p := people.People{
+		{Person: dwp.Person{ID: 1, FirstName: "Maurise"}, Match: people.MatchCity},
+		{Person: dwp.Person{ID: 2, FirstName: "Bendix"}, Match: people.MatchProximity},
+	}
+
+	t.Run("Given no selected fields then each person is written on its own line and flushed", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		if err := writeNDJSON(w, p, nil); err != nil {
+			t.Errorf("writeNDJSON() error = %v", err)
+		}
+
+		want := `{"ID":1,"first_name":"Maurise","last_name":"","Email":"","ip_address":"","Latitude":0,"Longitude":0,"match":"city"}` + "\n" +
+			`{"ID":2,"first_name":"Bendix","last_name":"","Email":"","ip_address":"","Latitude":0,"Longitude":0,"match":"proximity"}` + "\n"
+
+		if got := w.Body.String(); got != want {
+			t.Errorf("writeNDJSON() = %s, want %s", got, want)
+		}
+
+		if !w.Flushed {
+			t.Errorf("writeNDJSON() flushed = false, want true")
+		}
+
+		if got := w.Header().Get("Content-Type"); got != "application/x-ndjson" {
+			t.Errorf("writeNDJSON() Content-Type = %v, want application/x-ndjson", got)
+		}
+	})
+
+	t.Run("Given selected fields then only those fields are written", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		if err := writeNDJSON(w, p, []string{"ID", "match"}); err != nil {
+			t.Errorf("writeNDJSON() error = %v", err)
+		}
+
+		if want := "{\"ID\":1,\"match\":\"city\"}\n{\"ID\":2,\"match\":\"proximity\"}\n"; w.Body.String() != want {
+			t.Errorf("writeNDJSON() = %s, want %s", w.Body.String(), want)
+		}
+	})
+
+	t.Run("Given no people then nothing is written", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		if err := writeNDJSON(w, people.People{}, nil); err != nil || w.Body.Len() != 0 {
+			t.Errorf("writeNDJSON() = %s, %v, want empty body", w.Body.String(), err)
+		}
+	})
+}
+
+func TestHandlers_writeCSV(t *testing.T) {
+	distance := 12.5
+	p := people.People{
+		{Person: dwp.Person{ID: 1, FirstName: "Maurise", LastName: "O'Shieldon, Jr", Email: `"m"@squidoo.com`}, Match: people.MatchCity, Distance: &distance},
+		{Person: dwp.Person{ID: 2, FirstName: "Bendix"}, Match: people.MatchProximity},
+	}
+
+	t.Run("Given no configured columns then every field is written with escaping", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		if err := (Handlers{}).writeCSV(w, p, locatedPersonFields, nil); err != nil {
+			t.Errorf("writeCSV() error = %v", err)
+		}
+
+		want := "ID,first_name,last_name,Email,ip_address,Latitude,Longitude,match,distance\n" +
+			"1,Maurise,\"O'Shieldon, Jr\",\"\"\"m\"\"@squidoo.com\",,0,0,city,12.5\n" +
+			"2,Bendix,,,,0,0,proximity,\n"
+
+		b, _ := io.ReadAll(w.Result().Body)
+
+		if string(b) != want {
+			t.Errorf("writeCSV() = %q, want %q", b, want)
+		}
+
+		if got := w.Header().Get("Content-Type"); got != "text/csv; charset=utf-8" {
+			t.Errorf("writeCSV() Content-Type = %v, want text/csv; charset=utf-8", got)
+		}
+	})
+
+	t.Run("Given configured columns and selected fields then columns are in configured order", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		h := Handlers{CSVColumns: []string{"distance", "last_name", "ID", "first_name"}}
+
+		if err := h.writeCSV(w, p[1:], locatedPersonFields, []string{"ID", "first_name", "distance"}); err != nil {
+			t.Errorf("writeCSV() error = %v", err)
+		}
+
+		b, _ := io.ReadAll(w.Result().Body)
+
+		if want := "distance,ID,first_name\n,2,Bendix\n"; string(b) != want {
+			t.Errorf("writeCSV() = %q, want %q", b, want)
+		}
+	})
+
+	t.Run("Given values that start with a formula character then they are escaped", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		h := Handlers{CSVColumns: []string{"first_name", "last_name", "Email", "Longitude"}}
+		formulas := people.People{{Person: dwp.Person{FirstName: "=1+2", LastName: "-Lazenby", Email: "@squidoo.com", Longitude: -0.093145}}}
+
+		if err := h.writeCSV(w, formulas, locatedPersonFields, nil); err != nil {
+			t.Errorf("writeCSV() error = %v", err)
+		}
+
+		b, _ := io.ReadAll(w.Result().Body)
+
+		if want := "first_name,last_name,Email,Longitude\n'=1+2,'-Lazenby,'@squidoo.com,-0.093145\n"; string(b) != want {
+			t.Errorf("writeCSV() = %q, want %q", b, want)
+		}
+	})
+}
+
+func Test_escapeFormula(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"Maurise", "Maurise"},
+		{"", ""},
+		{`=HYPERLINK("http://example.com")`, `'=HYPERLINK("http://example.com")`},
+		{"+44 20 7946 0000", "'+44 20 7946 0000"},
+		{"-2+3", "'-2+3"},
+		{"@SUM(A1:A2)", "'@SUM(A1:A2)"},
+		{"\t=1", "'\t=1"},
+		{"-0.093145", "-0.093145"},
+		{"a=b", "a=b"},
+	}
+
+	for _, tt := range tests {
+		if got := escapeFormula(tt.s); got != tt.want {
+			t.Errorf("escapeFormula(%q) = %q, want %q", tt.s, got, tt.want)
+		}
+	}
+}
+
+func Test_csvColumns(t *testing.T) {
+	tests := []struct {
+		configured []string
+		known      []string
+		fields     []string
+		want       []string
+	}{
+		{nil, personFields, nil, personFields},
+		{[]string{"Email", "ID", "match"}, personFields, nil, []string{"Email", "ID"}},
+		{[]string{"Email", "ID", "match"}, locatedPersonFields, []string{"ID", "match"}, []string{"ID", "match"}},
+	}
+
+	for _, tt := range tests {
+		if got := csvColumns(tt.configured, tt.known, tt.fields); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("csvColumns() = %v, want %v", got, tt.want)
+		}
+	}
+}
+
+func TestParseCSVColumns(t *testing.T) {
+	got, err := ParseCSVColumns([]string{"id", "DISTANCE", "first_name"})
+	if err != nil || !reflect.DeepEqual(got, []string{"ID", "distance", "first_name"}) {
+		t.Errorf("ParseCSVColumns() = %v, %v, want [ID distance first_name]", got, err)
+	}
+
+	if _, err = ParseCSVColumns([]string{"id", "password"}); err == nil {
+		t.Errorf("ParseCSVColumns() error = nil, want error")
+	}
+}
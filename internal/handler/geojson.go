@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/J-R-Oliver/dwp-assessment-go/internal/people"
+	"github.com/umahmood/haversine"
+)
+
+const ContentTypeApplicationGeoJSON = "application/geo+json"
+
+type featureCollection struct {
+	Type     string    `json:"type"`
+	Features []feature `json:"features"`
+}
+
+type feature struct {
+	Type       string          `json:"type"`
+	Geometry   point           `json:"geometry"`
+	Properties json.RawMessage `json:"properties"`
+}
+
+// point is a GeoJSON Point geometry. Its coordinates are longitude followed by latitude.
+type point struct {
+	Type        string     `json:"type"`
+	Coordinates [2]float64 `json:"coordinates"`
+}
+
+// area is the circle around a city in which people were searched for.
+type area struct {
+	city   string
+	centre haversine.Coord
+	radius int
+	unit   people.Unit
+}
+
+type areaProperties struct {
+	City   string `json:"city"`
+	Radius int    `json:"radius"`
+	Unit   string `json:"unit"`
+}
+
+// writeGeoJSON writes a FeatureCollection with a Point feature for each person, whose properties are the selected
+// fields other than the coordinates. If searched is not nil it is written as a further feature at the city centre.
+func writeGeoJSON(w http.ResponseWriter, people interface{}, known []string, fields []string, searched *area) error {
+	records, err := toRecords(people)
+	if err != nil {
+		return err
+	}
+
+	if fields == nil {
+		fields = known
+	}
+
+	properties := make([]string, 0, len(fields))
+
+	for _, field := range fields {
+		if field != "Latitude" && field != "Longitude" {
+			properties = append(properties, field)
+		}
+	}
+
+	collection := featureCollection{Type: "FeatureCollection", Features: make([]feature, 0, len(records)+1)}
+
+	for _, rec := range records {
+		var lat, lon float64
+
+		if err = json.Unmarshal(rec["Latitude"], &lat); err != nil {
+			return err
+		}
+
+		if err = json.Unmarshal(rec["Longitude"], &lon); err != nil {
+			return err
+		}
+
+		collection.Features = append(collection.Features, newFeature(lat, lon, rec.object(properties)))
+	}
+
+	if searched != nil {
+		var b json.RawMessage
+
+		b, err = json.Marshal(areaProperties{City: searched.city, Radius: searched.radius, Unit: searched.unit.String()})
+		if err != nil {
+			return err
+		}
+
+		collection.Features = append(collection.Features, newFeature(searched.centre.Lat, searched.centre.Lon, b))
+	}
+
+	w.Header().Set("Content-Type", ContentTypeApplicationGeoJSON)
+
+	return json.NewEncoder(w).Encode(collection)
+}
+
+func newFeature(lat float64, lon float64, properties json.RawMessage) feature {
+	return feature{
+		Type:       "Feature",
+		Geometry:   point{Type: "Point", Coordinates: [2]float64{lon, lat}},
+		Properties: properties,
+	}
+}
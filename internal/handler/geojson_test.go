@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/J-R-Oliver/dwp-assessment-go/pkg/dwp"
+)
+
+func Test_writeGeoJSON(t *testing.T) {
+	p := dwp.People{{ID: 1, FirstName: "Maurise", Latitude: 34.003135, Longitude: -117.7228641}}
+
+	t.Run("Given no selected fields then every field other than the coordinates is a property", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		if err := writeGeoJSON(w, p, personFields, nil, nil); err != nil {
+			t.Errorf("writeGeoJSON() error = %v", err)
+		}
+
+		want := `{"type":"FeatureCollection","features":[{"type":"Feature",` +
+			`"geometry":{"type":"Point","coordinates":[-117.7228641,34.003135]},` +
+			`"properties":{"ID":1,"first_name":"Maurise","last_name":"","Email":"","ip_address":""}}]}` + "\n"
+
+		b, _ := io.ReadAll(w.Result().Body)
+
+		if string(b) != want {
+			t.Errorf("writeGeoJSON() = %s, want %s", b, want)
+		}
+	})
+
+	t.Run("Given no people then an empty feature collection is written", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		if err := writeGeoJSON(w, dwp.People{}, personFields, []string{"ID"}, nil); err != nil {
+			t.Errorf("writeGeoJSON() error = %v", err)
+		}
+
+		b, _ := io.ReadAll(w.Result().Body)
+
+		if want := `{"type":"FeatureCollection","features":[]}` + "\n"; string(b) != want {
+			t.Errorf("writeGeoJSON() = %s, want %s", b, want)
+		}
+	})
+}
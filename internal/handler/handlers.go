@@ -3,56 +3,98 @@ package handler
 import (
 	"context"
 	"encoding/json"
-	"fmt"
+	"errors"
+	"math"
 	"net/http"
+	"net/url"
 	"strconv"
-	"strings"
 	"time"
 
-	"github.com/J-R-Oliver/dwp-assessment-go/internal/configuration"
+	"github.com/J-R-Oliver/dwp-assessment-go/internal/people"
 	"github.com/J-R-Oliver/dwp-assessment-go/pkg/dwp"
 	"github.com/J-R-Oliver/dwp-assessment-go/pkg/logging"
+	"github.com/umahmood/haversine"
 )
 
 const ContentTypeApplicationJSON = "application/json"
 
+const (
+	bitSize      = 64
+	maxLatitude  = 90
+	maxLongitude = 180
+)
+
 type service interface {
-	RetrievePeople(ctx context.Context) (dwp.People, error)
-	RetrievePeopleByCity(ctx context.Context, city string, distance int) (dwp.People, error)
+	RetrievePeople(ctx context.Context, f people.Filter) (dwp.People, error)
+	RetrievePeopleByCity(ctx context.Context, city string, q people.Query) (people.People, error)
+	RetrievePeopleNear(ctx context.Context, coordinates haversine.Coord, q people.Query) (people.People, error)
+	RetrievePerson(ctx context.Context, id int) (dwp.Person, error)
+}
+
+type circuitBreaker interface {
+	State() dwp.CircuitState
 }
 
 type Handlers struct {
 	Service         service
 	DefaultDistance int
-	Cities          map[string]configuration.City
+	DefaultUnit     people.Unit
+	MinDistance     int
+	MaxDistance     int
+	DefaultPageSize int
+	MaxPageSize     int
+	CSVColumns      []string
+	Cities          map[string]City
+	CircuitBreaker  circuitBreaker
 	Logger          logging.Logger
 }
 
-func (h Handlers) GetPeople(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", ContentTypeApplicationJSON)
+// logger returns h.Logger with the fields carried by the request's context, which identify the request.
+func (h Handlers) logger(r *http.Request) logging.Logger {
+	return logging.FromContext(r.Context(), h.Logger)
+}
 
+type healthResponse struct {
+	Status         string            `json:"status"`
+	CircuitBreaker *dwp.CircuitState `json:"circuitBreaker,omitempty"`
+}
+
+func (h Handlers) GetPeople(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		h.methodNotAllow(w, r)
 		return
 	}
 
+	v := validator{}
+
+	query := r.URL.Query()
+
+	f := parseFilter(&v, query)
+	p := h.parsePage(&v, query)
+	fields := parseFields(&v, query, personFields)
+	responseFormat := negotiateFormat(&v, r, query)
+
+	if !v.valid() {
+		h.invalidParameters(w, r, v.invalid)
+		return
+	}
+
 	ctx := r.Context()
 
 	ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
 	defer cancel()
 
-	people, err := h.Service.RetrievePeople(ctx)
+	people, err := h.Service.RetrievePeople(ctx, f)
 	if err != nil {
-		h.InternalServerError(w, r, err)
+		h.serviceError(w, r, err)
 		return
 	}
 
-	err = json.NewEncoder(w).Encode(people)
-	if err != nil {
-		h.InternalServerError(w, r, err)
-	}
+	h.writePeople(w, r, paginate(w, r, people, p), personFields, fields, responseFormat, nil)
 }
 
+// GetPeopleByCity returns the people registered in, or within distance of, the city named by the path after
+// pathPrefix. A path of only an integer is a person's ID rather than a city, and the person is returned instead.
 func (h Handlers) GetPeopleByCity(pathPrefix string) func(http.ResponseWriter, *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
@@ -60,54 +102,194 @@ func (h Handlers) GetPeopleByCity(pathPrefix string) func(http.ResponseWriter, *
 			return
 		}
 
-		ctx := r.Context()
+		path := pathSegment(r, pathPrefix)
 
-		path := strings.TrimPrefix(r.URL.Path, pathPrefix)
-		path = strings.ToUpper(path[:1]) + path[1:]
-
-		var distance int
+		if id, err := strconv.Atoi(path); err == nil {
+			h.getPerson(w, r, id)
+			return
+		}
 
-		var err error
+		name, city, ok := h.city(path)
 
 		query := r.URL.Query()
 
-		distanceQuery := query.Get("distance")
-		if distanceQuery != "" {
-			distance, err = strconv.Atoi(distanceQuery)
-		} else {
-			distance = h.DefaultDistance
-		}
+		v := validator{}
 
-		if err != nil {
-			h.Logger.Info(fmt.Sprintf("bad query: %s distance", distanceQuery))
-			h.badRequest(w, r, fmt.Sprintf("Invalid distance query - %s is not an integer", distanceQuery))
+		q := h.parsePeopleQuery(&v, query, h.cityDistance(city))
+		p := h.parsePage(&v, query)
+		fields := parseFields(&v, query, locatedPersonFields)
+		responseFormat := negotiateFormat(&v, r, query)
 
+		if !v.valid() {
+			h.invalidParameters(w, r, v.invalid)
 			return
 		}
 
-		_, ok := h.Cities[path]
 		if !ok {
-			h.Logger.Info(fmt.Sprintf("city not found - %s", path))
+			h.logger(r).With(logging.Fields{"city": path}).Info("city not found")
 			h.notFound(w, r, "City Not Found")
 
 			return
 		}
 
-		people, err := h.Service.RetrievePeopleByCity(ctx, path, distance)
+		ctx := r.Context()
+
+		ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+		defer cancel()
+
+		cityPeople, err := h.Service.RetrievePeopleByCity(ctx, name, q)
 		if err != nil {
-			h.InternalServerError(w, r, err)
+			h.serviceError(w, r, err)
+			return
+		}
+
+		searched := &area{city: name, centre: city.Coordinates, radius: q.Distance, unit: q.Unit}
+
+		h.writePeople(w, r, paginate(w, r, cityPeople, p), locatedPersonFields, fields, responseFormat, searched)
+	}
+}
+
+// GetPerson returns the person whose ID is the path after pathPrefix.
+func (h Handlers) GetPerson(pathPrefix string) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			h.methodNotAllow(w, r)
 			return
 		}
 
-		w.Header().Set("Content-Type", ContentTypeApplicationJSON)
+		path := pathSegment(r, pathPrefix)
 
-		err = json.NewEncoder(w).Encode(people)
+		id, err := strconv.Atoi(path)
 		if err != nil {
-			h.InternalServerError(w, r, err)
+			h.logger(r).With(logging.Fields{"id": path}).Info("person not found")
+			h.notFound(w, r, "Person Not Found")
+
+			return
 		}
+
+		h.getPerson(w, r, id)
 	}
 }
 
+// getPerson returns the person with the ID, or a not found response if the person does not exist.
+func (h Handlers) getPerson(w http.ResponseWriter, r *http.Request, id int) {
+	v := validator{}
+
+	fields := parseFields(&v, r.URL.Query(), personFields)
+
+	if !v.valid() {
+		h.invalidParameters(w, r, v.invalid)
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+	defer cancel()
+
+	person, err := h.Service.RetrievePerson(ctx, id)
+
+	switch {
+	case errors.Is(err, dwp.ErrNotFound):
+		h.logger(r).With(logging.Fields{"id": id}).Info("person not found")
+		h.notFound(w, r, "Person Not Found")
+	case err != nil:
+		h.serviceError(w, r, err)
+	default:
+		h.writePerson(w, r, person, fields)
+	}
+}
+
+// GetPeopleNear returns the people within distance of the coordinates given by the lat and lon queries.
+func (h Handlers) GetPeopleNear(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.methodNotAllow(w, r)
+		return
+	}
+
+	query := r.URL.Query()
+
+	v := validator{}
+
+	lat := v.coordinate(query, "lat", maxLatitude)
+	lon := v.coordinate(query, "lon", maxLongitude)
+	q := h.parsePeopleQuery(&v, query, h.DefaultDistance)
+	p := h.parsePage(&v, query)
+	fields := parseFields(&v, query, locatedPersonFields)
+	responseFormat := negotiateFormat(&v, r, query)
+
+	if !v.valid() {
+		h.invalidParameters(w, r, v.invalid)
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+	defer cancel()
+
+	nearbyPeople, err := h.Service.RetrievePeopleNear(ctx, haversine.Coord{Lat: lat, Lon: lon}, q)
+	if err != nil {
+		h.serviceError(w, r, err)
+		return
+	}
+
+	h.writePeople(w, r, paginate(w, r, nearbyPeople, p), locatedPersonFields, fields, responseFormat, nil)
+}
+
+// parsePeopleQuery validates the distance, unit, sort and filter queries shared by the endpoints that retrieve people
+// around a location. The distance defaults to defaultDistance, in the default unit, and must be within the configured
+// bounds, converted to the requested unit.
+func (h Handlers) parsePeopleQuery(v *validator, query url.Values, defaultDistance int) people.Query {
+	unit := h.DefaultUnit
+
+	if unitQuery := query.Get("unit"); unitQuery != "" {
+		u, err := people.ParseUnit(unitQuery)
+		if err != nil {
+			v.reject("unit", err.Error())
+		} else {
+			unit = u
+		}
+	}
+
+	converted := int(math.Round(h.DefaultUnit.Convert(float64(defaultDistance), unit)))
+	minimum, maximum := h.distanceBounds(unit)
+
+	distance := v.integer(query, "distance", converted, minimum, maximum)
+
+	sort, err := people.ParseSort(query.Get("sort"))
+	if err != nil {
+		v.reject("sort", err.Error())
+	}
+
+	return people.Query{Distance: distance, Unit: unit, Sort: sort, Filter: parseFilter(v, query)}
+}
+
+// distanceBounds returns the configured minimum and maximum distance converted to unit. A MaxDistance of zero means
+// there is no maximum.
+func (h Handlers) distanceBounds(unit people.Unit) (int, int) {
+	minimum := int(math.Ceil(h.DefaultUnit.Convert(float64(h.MinDistance), unit)))
+
+	if h.MaxDistance == 0 {
+		return minimum, math.MaxInt
+	}
+
+	return minimum, int(math.Floor(h.DefaultUnit.Convert(float64(h.MaxDistance), unit)))
+}
+
+// Health reports that the service is up, along with the state of the upstream circuit breaker if one is configured.
 func (h Handlers) Health(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNoContent)
+	response := healthResponse{Status: "up"}
+
+	if h.CircuitBreaker != nil {
+		state := h.CircuitBreaker.State()
+		response.CircuitBreaker = &state
+	}
+
+	w.Header().Set("Content-Type", ContentTypeApplicationJSON)
+
+	err := json.NewEncoder(w).Encode(response)
+	if err != nil {
+		h.InternalServerError(w, r, err)
+	}
 }
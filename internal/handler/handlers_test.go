@@ -4,31 +4,44 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
-	"github.com/J-R-Oliver/dwp-assessment-go/internal/configuration"
+	"github.com/J-R-Oliver/dwp-assessment-go/internal/people"
 	"github.com/J-R-Oliver/dwp-assessment-go/pkg/dwp"
 	"github.com/J-R-Oliver/dwp-assessment-go/pkg/logging"
+	"github.com/umahmood/haversine"
 )
 
 const london = "London"
 
-var mockRetrievePeople func() (dwp.People, error)
-var mockRetrievePeopleByCity func(city string, distance int) (dwp.People, error)
+var mockRetrievePeople func(f people.Filter) (dwp.People, error)
+var mockRetrievePeopleByCity func(city string, q people.Query) (people.People, error)
+var mockRetrievePeopleNear func(coordinates haversine.Coord, q people.Query) (people.People, error)
+var mockRetrievePerson func(id int) (dwp.Person, error)
 
 type mockService struct{}
 
-func (m mockService) RetrievePeople(ctx context.Context) (dwp.People, error) {
-	return mockRetrievePeople()
+func (m mockService) RetrievePeople(ctx context.Context, f people.Filter) (dwp.People, error) {
+	return mockRetrievePeople(f)
 }
 
-func (m mockService) RetrievePeopleByCity(ctx context.Context, city string, distance int) (dwp.People, error) {
-	return mockRetrievePeopleByCity(city, distance)
+func (m mockService) RetrievePeopleByCity(ctx context.Context, city string, q people.Query) (people.People, error) {
+	return mockRetrievePeopleByCity(city, q)
+}
+
+func (m mockService) RetrievePerson(ctx context.Context, id int) (dwp.Person, error) {
+	return mockRetrievePerson(id)
+}
+
+func (m mockService) RetrievePeopleNear(ctx context.Context, coordinates haversine.Coord, q people.Query) (people.People, error) {
+	return mockRetrievePeopleNear(coordinates, q)
 }
 
 func TestHandlers_GetPeople(t *testing.T) {
@@ -36,7 +49,7 @@ func TestHandlers_GetPeople(t *testing.T) {
 		w := httptest.NewRecorder()
 		r := httptest.NewRequest(http.MethodGet, "/api/people", nil)
 
-		mockRetrievePeople = func() (dwp.People, error) {
+		mockRetrievePeople = func(f people.Filter) (dwp.People, error) {
 			p := dwp.People{
 				{
 					ID:        1,
@@ -93,6 +106,155 @@ func TestHandlers_GetPeople(t *testing.T) {
 		}
 	})
 
+	t.Run("Given a request with limit and offset queries then page of people is returned", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/api/people?limit=1&offset=1", nil)
+
+		mockRetrievePeople = func(f people.Filter) (dwp.People, error) {
+			return dwp.People{{ID: 1}, {ID: 2}, {ID: 3}}, nil
+		}
+
+		h := Handlers{
+			Service:         mockService{},
+			DefaultPageSize: 10,
+			MaxPageSize:     10,
+			Logger:          nil,
+		}
+		h.GetPeople(w, r)
+
+		resp := w.Result()
+
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("GetPeople() = %v, want %v", resp.StatusCode, http.StatusOK)
+		}
+
+		if resp.Header.Get("X-Total-Count") != "3" {
+			t.Errorf("GetPeople() X-Total-Count = %v, want 3", resp.Header.Get("X-Total-Count"))
+		}
+
+		if !strings.Contains(resp.Header.Get("Link"), `</api/people?limit=1&offset=2>; rel="next"`) {
+			t.Errorf("GetPeople() Link = %v, want next page link", resp.Header.Get("Link"))
+		}
+
+		b, _ := io.ReadAll(resp.Body)
+
+		if !strings.HasPrefix(string(b), `[{"ID":2,`) || strings.Count(string(b), `"ID"`) != 1 {
+			t.Errorf("GetPeople() = %s, want person 2 only", b)
+		}
+	})
+
+	t.Run("Given a request with filter queries then filter is passed to service", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/api/people?last_name_prefix=Hal&id_max=10", nil)
+
+		mockRetrievePeople = func(f people.Filter) (dwp.People, error) {
+			if f != (people.Filter{LastNamePrefix: "Hal", MaxID: 10}) {
+				t.Errorf("GetPeople() = %v, want {LastNamePrefix: Hal, MaxID: 10}", f)
+			}
+
+			return dwp.People{}, nil
+		}
+
+		h := Handlers{
+			Service: mockService{},
+			Logger:  nil,
+		}
+		h.GetPeople(w, r)
+
+		resp := w.Result()
+
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("GetPeople() = %v, want %v", resp.StatusCode, http.StatusOK)
+		}
+	})
+
+	t.Run("Given a request with fields query then only those fields are returned", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/api/people?fields=id,latitude,longitude", nil)
+
+		mockRetrievePeople = func(f people.Filter) (dwp.People, error) {
+			return dwp.People{{ID: 1, FirstName: "Maurise", Email: "mshieldon0@squidoo.com", Latitude: 34.5, Longitude: -117.5}}, nil
+		}
+
+		h := Handlers{
+			Service: mockService{},
+			Logger:  nil,
+		}
+		h.GetPeople(w, r)
+
+		resp := w.Result()
+
+		defer resp.Body.Close()
+
+		b, _ := io.ReadAll(resp.Body)
+
+		if want := `[{"ID":1,"Latitude":34.5,"Longitude":-117.5}]` + "\n"; string(b) != want {
+			t.Errorf("GetPeople() = %s, want %s", b, want)
+		}
+	})
+
+	t.Run("Given a request with an unknown field then bad request response", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/api/people?fields=id,distance", nil)
+
+		h := Handlers{
+			Service: mockService{},
+			Logger:  logging.New(logging.Info),
+		}
+		h.GetPeople(w, r)
+
+		resp := w.Result()
+
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("GetPeople() = %v, want %v", resp.StatusCode, http.StatusBadRequest)
+		}
+
+		b, _ := io.ReadAll(resp.Body)
+		body := string(b)
+
+		expectedBody := `"errors":[{"parameter":"fields","reason":"distance is not a known field`
+
+		if !strings.Contains(body, expectedBody) {
+			t.Errorf("GetPeople() = %v, want %v", body, expectedBody)
+		}
+	})
+
+	t.Run("Given a request with a limit above the maximum page size then bad request response", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/api/people?limit=11", nil)
+
+		h := Handlers{
+			Service:         mockService{},
+			DefaultPageSize: 10,
+			MaxPageSize:     10,
+			Logger:          logging.New(logging.Info),
+		}
+		h.GetPeople(w, r)
+
+		resp := w.Result()
+
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("GetPeople() = %v, want %v", resp.StatusCode, http.StatusBadRequest)
+		}
+
+		b, _ := io.ReadAll(resp.Body)
+		body := string(b)
+
+		expectedBody := `"errors":[{"parameter":"limit","reason":"11 is greater than the maximum of 10"}]`
+
+		if !strings.Contains(body, expectedBody) {
+			t.Errorf("GetPeople() = %v, want %v", body, expectedBody)
+		}
+	})
+
 	t.Run("Given a request with HTTP method post then method not allowed response", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		r := httptest.NewRequest(http.MethodPost, "/api/people", nil)
@@ -131,7 +293,7 @@ func TestHandlers_GetPeople(t *testing.T) {
 		w := httptest.NewRecorder()
 		r := httptest.NewRequest(http.MethodGet, "/api/people", nil)
 
-		mockRetrievePeople = func() (dwp.People, error) {
+		mockRetrievePeople = func(f people.Filter) (dwp.People, error) {
 			return nil, errors.New("test error")
 		}
 
@@ -164,6 +326,44 @@ func TestHandlers_GetPeople(t *testing.T) {
 			t.Errorf("GetPeople() = %v, want %v", body, expectedBody)
 		}
 	})
+
+	t.Run("Given a valid request when the circuit breaker is open then service unavailable response", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/api/people", nil)
+
+		mockRetrievePeople = func(f people.Filter) (dwp.People, error) {
+			return nil, &dwp.CircuitOpenError{RetryAfter: 1500 * time.Millisecond}
+		}
+
+		h := Handlers{
+			Service:         mockService{},
+			DefaultDistance: 0,
+			Cities:          nil,
+			Logger:          logging.New(logging.Info),
+		}
+		h.GetPeople(w, r)
+
+		resp := w.Result()
+
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusServiceUnavailable {
+			t.Errorf("GetPeople() = %v, want %v", resp.StatusCode, http.StatusServiceUnavailable)
+		}
+
+		if resp.Header.Get("Retry-After") != "2" {
+			t.Errorf("GetPeople() Retry-After = %v, want 2", resp.Header.Get("Retry-After"))
+		}
+
+		b, _ := io.ReadAll(resp.Body)
+		body := string(b)
+
+		expectedBody := `"status":503,"message":"Service Unavailable","path":"/api/people"`
+
+		if !strings.Contains(body, expectedBody) {
+			t.Errorf("GetPeople() = %v, want %v", body, expectedBody)
+		}
+	})
 }
 
 func TestHandlers_GetPeopleByCity(t *testing.T) {
@@ -171,33 +371,39 @@ func TestHandlers_GetPeopleByCity(t *testing.T) {
 		w := httptest.NewRecorder()
 		r := httptest.NewRequest(http.MethodGet, "/api/people/london", nil)
 
-		mockRetrievePeopleByCity = func(city string, distance int) (dwp.People, error) {
+		mockRetrievePeopleByCity = func(city string, q people.Query) (people.People, error) {
 			if city != london {
 				t.Errorf("GetPeopleByCity() = %v, London", city)
 			}
 
-			if distance != 50 {
-				t.Errorf("GetPeopleByCity() = %v, 50", distance)
+			if q.Distance != 50 {
+				t.Errorf("GetPeopleByCity() = %v, 50", q.Distance)
 			}
 
-			p := dwp.People{
+			p := people.People{
 				{
-					ID:        1,
-					FirstName: "Maurise",
-					LastName:  "Shieldon",
-					Email:     "mshieldon0@squidoo.com",
-					IPAddress: "192.57.232.111",
-					Latitude:  dwp.Coordinate(34.003135),
-					Longitude: dwp.Coordinate(-117.7228641),
+					Person: dwp.Person{
+						ID:        1,
+						FirstName: "Maurise",
+						LastName:  "Shieldon",
+						Email:     "mshieldon0@squidoo.com",
+						IPAddress: "192.57.232.111",
+						Latitude:  dwp.Coordinate(34.003135),
+						Longitude: dwp.Coordinate(-117.7228641),
+					},
+					Match: people.MatchCity,
 				},
 				{
-					ID:        2,
-					FirstName: "Bendix",
-					LastName:  "Halgarth",
-					Email:     "bhalgarth1@timesonline.co.uk",
-					IPAddress: "4.185.73.82",
-					Latitude:  dwp.Coordinate(-2.9623869),
-					Longitude: dwp.Coordinate(104.7399789),
+					Person: dwp.Person{
+						ID:        2,
+						FirstName: "Bendix",
+						LastName:  "Halgarth",
+						Email:     "bhalgarth1@timesonline.co.uk",
+						IPAddress: "4.185.73.82",
+						Latitude:  dwp.Coordinate(-2.9623869),
+						Longitude: dwp.Coordinate(104.7399789),
+					},
+					Match: people.MatchBoth,
 				},
 			}
 			return p, nil
@@ -206,7 +412,7 @@ func TestHandlers_GetPeopleByCity(t *testing.T) {
 		h := Handlers{
 			Service:         mockService{},
 			DefaultDistance: 50,
-			Cities:          map[string]configuration.City{london: {}},
+			Cities:          map[string]City{london: {}},
 			Logger:          nil,
 		}
 		h.GetPeopleByCity("/api/people/")(w, r)
@@ -225,9 +431,9 @@ func TestHandlers_GetPeopleByCity(t *testing.T) {
 
 		b, _ := io.ReadAll(resp.Body)
 
-		f, err := os.ReadFile("testdata/people.txt")
+		f, err := os.ReadFile("testdata/people-by-city.txt")
 		if err != nil {
-			t.Errorf("GetPeopleByCity() error reading testdata/people.txt = %v", err)
+			t.Errorf("GetPeopleByCity() error reading testdata/people-by-city.txt = %v", err)
 			return
 		}
 
@@ -240,33 +446,39 @@ func TestHandlers_GetPeopleByCity(t *testing.T) {
 		w := httptest.NewRecorder()
 		r := httptest.NewRequest(http.MethodGet, "/api/people/london?distance=5", nil)
 
-		mockRetrievePeopleByCity = func(city string, distance int) (dwp.People, error) {
+		mockRetrievePeopleByCity = func(city string, q people.Query) (people.People, error) {
 			if city != london {
 				t.Errorf("GetPeopleByCity() = %v, London", city)
 			}
 
-			if distance != 5 {
-				t.Errorf("GetPeopleByCity() = %v, 5", distance)
+			if q.Distance != 5 {
+				t.Errorf("GetPeopleByCity() = %v, 5", q.Distance)
 			}
 
-			p := dwp.People{
+			p := people.People{
 				{
-					ID:        1,
-					FirstName: "Maurise",
-					LastName:  "Shieldon",
-					Email:     "mshieldon0@squidoo.com",
-					IPAddress: "192.57.232.111",
-					Latitude:  dwp.Coordinate(34.003135),
-					Longitude: dwp.Coordinate(-117.7228641),
+					Person: dwp.Person{
+						ID:        1,
+						FirstName: "Maurise",
+						LastName:  "Shieldon",
+						Email:     "mshieldon0@squidoo.com",
+						IPAddress: "192.57.232.111",
+						Latitude:  dwp.Coordinate(34.003135),
+						Longitude: dwp.Coordinate(-117.7228641),
+					},
+					Match: people.MatchCity,
 				},
 				{
-					ID:        2,
-					FirstName: "Bendix",
-					LastName:  "Halgarth",
-					Email:     "bhalgarth1@timesonline.co.uk",
-					IPAddress: "4.185.73.82",
-					Latitude:  dwp.Coordinate(-2.9623869),
-					Longitude: dwp.Coordinate(104.7399789),
+					Person: dwp.Person{
+						ID:        2,
+						FirstName: "Bendix",
+						LastName:  "Halgarth",
+						Email:     "bhalgarth1@timesonline.co.uk",
+						IPAddress: "4.185.73.82",
+						Latitude:  dwp.Coordinate(-2.9623869),
+						Longitude: dwp.Coordinate(104.7399789),
+					},
+					Match: people.MatchBoth,
 				},
 			}
 			return p, nil
@@ -275,7 +487,7 @@ func TestHandlers_GetPeopleByCity(t *testing.T) {
 		h := Handlers{
 			Service:         mockService{},
 			DefaultDistance: 0,
-			Cities:          map[string]configuration.City{london: {}},
+			Cities:          map[string]City{london: {}},
 			Logger:          nil,
 		}
 		h.GetPeopleByCity("/api/people/")(w, r)
@@ -294,9 +506,9 @@ func TestHandlers_GetPeopleByCity(t *testing.T) {
 
 		b, _ := io.ReadAll(resp.Body)
 
-		f, err := os.ReadFile("testdata/people.txt")
+		f, err := os.ReadFile("testdata/people-by-city.txt")
 		if err != nil {
-			t.Errorf("GetPeopleByCity() error reading testdata/people.txt = %v", err)
+			t.Errorf("GetPeopleByCity() error reading testdata/people-by-city.txt = %v", err)
 			return
 		}
 
@@ -366,7 +578,140 @@ func TestHandlers_GetPeopleByCity(t *testing.T) {
 		b, _ := io.ReadAll(resp.Body)
 		body := string(b)
 
-		expectedBody := `"status":400,"message":"Invalid distance query - not-an-int is not an integer","path":"/api/people/london"`
+		expectedBody := `"status":400,"message":"Invalid Query Parameters","path":"/api/people/london","errors":[{"parameter":"distance","reason":"not-an-int is not an integer"}]`
+
+		if !strings.Contains(body, expectedBody) {
+			t.Errorf("GetPeopleByCity() = %v, want %v", body, expectedBody)
+		}
+	})
+
+	t.Run("Given a valid request with sort query then sort is passed to service", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/api/people/london?sort=-distance", nil)
+
+		mockRetrievePeopleByCity = func(city string, q people.Query) (people.People, error) {
+			if q.Sort != people.SortDistanceDescending {
+				t.Errorf("GetPeopleByCity() = %v, -distance", q.Sort)
+			}
+
+			return people.People{}, nil
+		}
+
+		h := Handlers{
+			Service:         mockService{},
+			DefaultDistance: 50,
+			Cities:          map[string]City{london: {}},
+			Logger:          nil,
+		}
+		h.GetPeopleByCity("/api/people/")(w, r)
+
+		resp := w.Result()
+
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("GetPeopleByCity() = %v, want %v", resp.StatusCode, http.StatusOK)
+		}
+	})
+
+	t.Run("Given a request accepting CSV then people are returned as CSV", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/api/people/london?fields=id,match", nil)
+		r.Header.Set("Accept", "text/csv")
+
+		mockRetrievePeopleByCity = func(city string, q people.Query) (people.People, error) {
+			return people.People{{Person: dwp.Person{ID: 1}, Match: people.MatchBoth}}, nil
+		}
+
+		h := Handlers{
+			Service:         mockService{},
+			DefaultDistance: 50,
+			Cities:          map[string]City{london: {}},
+			Logger:          nil,
+		}
+		h.GetPeopleByCity("/api/people/")(w, r)
+
+		resp := w.Result()
+
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("GetPeopleByCity() = %v, want %v", resp.StatusCode, http.StatusOK)
+		}
+
+		if resp.Header.Get("Content-Type") != "text/csv; charset=utf-8" {
+			t.Errorf("GetPeopleByCity() = %v, want text/csv; charset=utf-8", resp.Header.Get("Content-Type"))
+		}
+
+		b, _ := io.ReadAll(resp.Body)
+
+		if want := "ID,match\n1,both\n"; string(b) != want {
+			t.Errorf("GetPeopleByCity() = %q, want %q", b, want)
+		}
+	})
+
+	t.Run("Given a request accepting GeoJSON then people and searched area are returned as features", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/api/people/london?fields=id&distance=20", nil)
+		r.Header.Set("Accept", "application/geo+json")
+
+		mockRetrievePeopleByCity = func(city string, q people.Query) (people.People, error) {
+			return people.People{{Person: dwp.Person{ID: 1, Latitude: 51.5, Longitude: -0.1}, Match: people.MatchBoth}}, nil
+		}
+
+		h := Handlers{
+			Service:         mockService{},
+			DefaultDistance: 50,
+			MaxDistance:     100,
+			Cities:          map[string]City{london: {Coordinates: haversine.Coord{Lat: 51.507222, Lon: -0.1275}}},
+			Logger:          nil,
+		}
+		h.GetPeopleByCity("/api/people/")(w, r)
+
+		resp := w.Result()
+
+		defer resp.Body.Close()
+
+		if resp.Header.Get("Content-Type") != "application/geo+json" {
+			t.Errorf("GetPeopleByCity() = %v, want application/geo+json", resp.Header.Get("Content-Type"))
+		}
+
+		b, _ := io.ReadAll(resp.Body)
+
+		want := `{"type":"FeatureCollection","features":[` +
+			`{"type":"Feature","geometry":{"type":"Point","coordinates":[-0.1,51.5]},"properties":{"ID":1}},` +
+			`{"type":"Feature","geometry":{"type":"Point","coordinates":[-0.1275,51.507222]},` +
+			`"properties":{"city":"London","radius":20,"unit":"mi"}}]}` + "\n"
+
+		if string(b) != want {
+			t.Errorf("GetPeopleByCity() = %s, want %s", b, want)
+		}
+	})
+
+	t.Run("Given a request with an invalid sort query then bad request response", func(t *testing.T) { //nolint:dupl
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/api/people/london?sort=name", nil)
+
+		h := Handlers{
+			Service:         mockService{},
+			DefaultDistance: 0,
+			Cities:          nil,
+			Logger:          logging.New(logging.Info),
+		}
+		h.GetPeopleByCity("/api/people/")(w, r)
+
+		resp := w.Result()
+
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("GetPeopleByCity() = %v, want %v", resp.StatusCode, http.StatusBadRequest)
+		}
+
+		b, _ := io.ReadAll(resp.Body)
+		body := string(b)
+
+		expectedBody := `"status":400,"message":"Invalid Query Parameters","path":"/api/people/london","errors":[{"parameter":"sort","reason":"name is not a valid sort - valid options are id, distance or -distance"}]`
 
 		if !strings.Contains(body, expectedBody) {
 			t.Errorf("GetPeopleByCity() = %v, want %v", body, expectedBody)
@@ -411,13 +756,13 @@ func TestHandlers_GetPeopleByCity(t *testing.T) {
 		w := httptest.NewRecorder()
 		r := httptest.NewRequest(http.MethodGet, "/api/people/london", nil)
 
-		mockRetrievePeopleByCity = func(city string, distance int) (dwp.People, error) {
+		mockRetrievePeopleByCity = func(city string, q people.Query) (people.People, error) {
 			if city != london {
 				t.Errorf("GetPeopleByCity() = %v, London", city)
 			}
 
-			if distance != 50 {
-				t.Errorf("GetPeopleByCity() = %v, 50", distance)
+			if q.Distance != 50 {
+				t.Errorf("GetPeopleByCity() = %v, 50", q.Distance)
 			}
 
 			return nil, errors.New("test error")
@@ -426,7 +771,7 @@ func TestHandlers_GetPeopleByCity(t *testing.T) {
 		h := Handlers{
 			Service:         mockService{},
 			DefaultDistance: 50,
-			Cities:          map[string]configuration.City{london: {}},
+			Cities:          map[string]City{london: {}},
 			Logger:          logging.New(logging.Info),
 		}
 		h.GetPeopleByCity("/api/people/")(w, r)
@@ -454,24 +799,404 @@ func TestHandlers_GetPeopleByCity(t *testing.T) {
 	})
 }
 
+func TestHandlers_GetPeopleByCity_person(t *testing.T) {
+	t.Run("Given a request for an ID then the person is returned", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/api/people/1?fields=id,first_name", nil)
+
+		mockRetrievePerson = func(id int) (dwp.Person, error) {
+			if id != 1 {
+				t.Errorf("GetPeopleByCity() id = %v, want 1", id)
+			}
+
+			return dwp.Person{ID: 1, FirstName: "Maurise", LastName: "Shieldon"}, nil
+		}
+
+		h := Handlers{
+			Service: mockService{},
+			Logger:  logging.New(logging.Info),
+		}
+		h.GetPeopleByCity("/api/people/")(w, r)
+
+		resp := w.Result()
+
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("GetPeopleByCity() = %v, want %v", resp.StatusCode, http.StatusOK)
+		}
+
+		b, _ := io.ReadAll(resp.Body)
+
+		if want := `{"ID":1,"first_name":"Maurise"}` + "\n"; string(b) != want {
+			t.Errorf("GetPeopleByCity() = %s, want %s", b, want)
+		}
+	})
+
+	t.Run("Given a request for an ID when the person does not exist then not found response", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/api/people/1001", nil)
+
+		mockRetrievePerson = func(id int) (dwp.Person, error) {
+			return dwp.Person{}, fmt.Errorf("wrapped: %w", &dwp.APIError{StatusCode: http.StatusNotFound})
+		}
+
+		h := Handlers{
+			Service: mockService{},
+			Logger:  logging.New(logging.Info),
+		}
+		h.GetPeopleByCity("/api/people/")(w, r)
+
+		resp := w.Result()
+
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusNotFound {
+			t.Errorf("GetPeopleByCity() = %v, want %v", resp.StatusCode, http.StatusNotFound)
+		}
+
+		b, _ := io.ReadAll(resp.Body)
+
+		if want := `"message":"Person Not Found"`; !strings.Contains(string(b), want) {
+			t.Errorf("GetPeopleByCity() = %s, want %s", b, want)
+		}
+	})
+
+	t.Run("Given a request for an ID when the upstream API fails then bad gateway response", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/api/people/1", nil)
+
+		mockRetrievePerson = func(id int) (dwp.Person, error) {
+			return dwp.Person{}, &dwp.APIError{StatusCode: http.StatusInternalServerError}
+		}
+
+		h := Handlers{
+			Service: mockService{},
+			Logger:  logging.New(logging.Info),
+		}
+		h.GetPeopleByCity("/api/people/")(w, r)
+
+		if w.Code != http.StatusBadGateway {
+			t.Errorf("GetPeopleByCity() = %v, want %v", w.Code, http.StatusBadGateway)
+		}
+	})
+}
+
+func TestHandlers_GetPerson(t *testing.T) {
+	t.Run("Given a request for an ID then the person is returned", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/api/persons/2", nil)
+
+		mockRetrievePerson = func(id int) (dwp.Person, error) {
+			return dwp.Person{ID: id}, nil
+		}
+
+		h := Handlers{
+			Service: mockService{},
+			Logger:  logging.New(logging.Info),
+		}
+		h.GetPerson("/api/persons/")(w, r)
+
+		if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"ID":2`) {
+			t.Errorf("GetPerson() = %v %s, want %v", w.Code, w.Body.String(), http.StatusOK)
+		}
+	})
+
+	t.Run("Given a request for a path that is not an ID then not found response", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/api/persons/london", nil)
+
+		h := Handlers{
+			Service: mockService{},
+			Logger:  logging.New(logging.Info),
+		}
+		h.GetPerson("/api/persons/")(w, r)
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("GetPerson() = %v, want %v", w.Code, http.StatusNotFound)
+		}
+	})
+
+	t.Run("Given a request with a method other than GET then method not allowed response", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/api/persons/1", nil)
+
+		h := Handlers{
+			Service: mockService{},
+			Logger:  logging.New(logging.Info),
+		}
+		h.GetPerson("/api/persons/")(w, r)
+
+		if w.Code != http.StatusMethodNotAllowed {
+			t.Errorf("GetPerson() = %v, want %v", w.Code, http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+func TestHandlers_GetPeopleNear(t *testing.T) {
+	t.Run("Given a valid request then people near the coordinates are returned", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/api/people/near?lat=51.5&lon=-0.1&distance=10&sort=distance", nil)
+
+		mockRetrievePeopleNear = func(coordinates haversine.Coord, q people.Query) (people.People, error) {
+			if coordinates != (haversine.Coord{Lat: 51.5, Lon: -0.1}) {
+				t.Errorf("GetPeopleNear() = %v, want {51.5 -0.1}", coordinates)
+			}
+
+			if q.Distance != 10 || q.Sort != people.SortDistanceAscending {
+				t.Errorf("GetPeopleNear() = %v, want {10 distance}", q)
+			}
+
+			return people.People{}, nil
+		}
+
+		h := Handlers{
+			Service:         mockService{},
+			DefaultDistance: 50,
+			Cities:          nil,
+			Logger:          nil,
+		}
+		h.GetPeopleNear(w, r)
+
+		resp := w.Result()
+
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("GetPeopleNear() = %v, want %v", resp.StatusCode, http.StatusOK)
+		}
+
+		if resp.Header.Get("Content-Type") != ContentTypeApplicationJSON {
+			t.Errorf("GetPeopleNear() = %v, want %v", resp.Header.Get("Content-Type"), ContentTypeApplicationJSON)
+		}
+
+		b, _ := io.ReadAll(resp.Body)
+
+		if string(b) != "[]\n" {
+			t.Errorf("GetPeopleNear() = %s, want []", b)
+		}
+	})
+
+	t.Run("Given a request without distance query then default distance is used", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/api/people/near?lat=0&lon=0", nil)
+
+		mockRetrievePeopleNear = func(coordinates haversine.Coord, q people.Query) (people.People, error) {
+			if q.Distance != 50 {
+				t.Errorf("GetPeopleNear() = %v, want 50", q.Distance)
+			}
+
+			return people.People{}, nil
+		}
+
+		h := Handlers{
+			Service:         mockService{},
+			DefaultDistance: 50,
+			Cities:          nil,
+			Logger:          nil,
+		}
+		h.GetPeopleNear(w, r)
+
+		resp := w.Result()
+
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("GetPeopleNear() = %v, want %v", resp.StatusCode, http.StatusOK)
+		}
+	})
+
+	t.Run("Given a request with unit query then distance is in that unit", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/api/people/near?lat=0&lon=0&unit=km&distance=5", nil)
+
+		mockRetrievePeopleNear = func(coordinates haversine.Coord, q people.Query) (people.People, error) {
+			if q.Distance != 5 || q.Unit != people.UnitKilometres {
+				t.Errorf("GetPeopleNear() = %v %v, want 5 km", q.Distance, q.Unit)
+			}
+
+			return people.People{}, nil
+		}
+
+		h := Handlers{
+			Service:         mockService{},
+			DefaultDistance: 50,
+			Cities:          nil,
+			Logger:          nil,
+		}
+		h.GetPeopleNear(w, r)
+
+		resp := w.Result()
+
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("GetPeopleNear() = %v, want %v", resp.StatusCode, http.StatusOK)
+		}
+	})
+
+	t.Run("Given a request with unit query and without distance query then default distance is converted", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/api/people/near?lat=0&lon=0&unit=km", nil)
+
+		mockRetrievePeopleNear = func(coordinates haversine.Coord, q people.Query) (people.People, error) {
+			if q.Distance != 80 || q.Unit != people.UnitKilometres {
+				t.Errorf("GetPeopleNear() = %v %v, want 80 km", q.Distance, q.Unit)
+			}
+
+			return people.People{}, nil
+		}
+
+		h := Handlers{
+			Service:         mockService{},
+			DefaultDistance: 50,
+			DefaultUnit:     people.UnitMiles,
+			Cities:          nil,
+			Logger:          nil,
+		}
+		h.GetPeopleNear(w, r)
+
+		resp := w.Result()
+
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("GetPeopleNear() = %v, want %v", resp.StatusCode, http.StatusOK)
+		}
+	})
+
+	t.Run("Given a request with HTTP method post then method not allowed response", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/api/people/near?lat=0&lon=0", nil)
+
+		h := Handlers{
+			Service:         mockService{},
+			DefaultDistance: 0,
+			Cities:          nil,
+			Logger:          nil,
+		}
+		h.GetPeopleNear(w, r)
+
+		resp := w.Result()
+
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusMethodNotAllowed {
+			t.Errorf("GetPeopleNear() = %v, want %v", resp.StatusCode, http.StatusMethodNotAllowed)
+		}
+	})
+
+	tests := []struct {
+		name   string
+		target string
+		errors string
+	}{
+		{"missing latitude", "/api/people/near?lon=0", `[{"parameter":"lat","reason":"is required"}]`},
+		{"invalid latitude", "/api/people/near?lat=north&lon=0", `[{"parameter":"lat","reason":"north is not a number between -90 and 90"}]`},
+		{"latitude out of range", "/api/people/near?lat=90.5&lon=0", `[{"parameter":"lat","reason":"90.5 is not a number between -90 and 90"}]`},
+		{"missing longitude", "/api/people/near?lat=0", `[{"parameter":"lon","reason":"is required"}]`},
+		{"longitude out of range", "/api/people/near?lat=0&lon=-181", `[{"parameter":"lon","reason":"-181 is not a number between -180 and 180"}]`},
+		{"invalid distance", "/api/people/near?lat=0&lon=0&distance=far", `[{"parameter":"distance","reason":"far is not an integer"}]`},
+		{"distance below minimum", "/api/people/near?lat=0&lon=0&distance=0", `[{"parameter":"distance","reason":"0 is less than the minimum of 1"}]`},
+		{"distance above maximum", "/api/people/near?lat=0&lon=0&distance=101", `[{"parameter":"distance","reason":"101 is greater than the maximum of 100"}]`},
+		{"distance above maximum in kilometres", "/api/people/near?lat=0&lon=0&distance=161&unit=km", `[{"parameter":"distance","reason":"161 is greater than the maximum of 160"}]`},
+		{"invalid unit", "/api/people/near?lat=0&lon=0&unit=yd", `[{"parameter":"unit","reason":"yd is not a valid unit - valid options are mi, km or m"}]`},
+		{"several invalid queries", "/api/people/near?lat=100&distance=-5&sort=name", `[{"parameter":"lat","reason":"100 is not a number between -90 and 90"},{"parameter":"lon","reason":"is required"},{"parameter":"distance","reason":"-5 is less than the minimum of 1"},{"parameter":"sort","reason":"name is not a valid sort - valid options are id, distance or -distance"}]`},
+	}
+
+	for _, tt := range tests {
+		t.Run("Given a request with "+tt.name+" then bad request response", func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, tt.target, nil)
+
+			h := Handlers{
+				Service:         mockService{},
+				DefaultDistance: 50,
+				MinDistance:     1,
+				MaxDistance:     100,
+				Cities:          nil,
+				Logger:          logging.New(logging.Info),
+			}
+			h.GetPeopleNear(w, r)
+
+			resp := w.Result()
+
+			defer resp.Body.Close()
+
+			if resp.StatusCode != http.StatusBadRequest {
+				t.Errorf("GetPeopleNear() = %v, want %v", resp.StatusCode, http.StatusBadRequest)
+			}
+
+			b, _ := io.ReadAll(resp.Body)
+			body := string(b)
+
+			expectedBody := `"status":400,"message":"Invalid Query Parameters","path":"/api/people/near","errors":` + tt.errors
+
+			if !strings.Contains(body, expectedBody) {
+				t.Errorf("GetPeopleNear() = %v, want %v", body, expectedBody)
+			}
+		})
+	}
+}
+
+type mockCircuitBreaker struct {
+	state dwp.CircuitState
+}
+
+func (m mockCircuitBreaker) State() dwp.CircuitState {
+	return m.state
+}
+
 func TestHandlers_Health(t *testing.T) {
-	w := httptest.NewRecorder()
-	r := httptest.NewRequest(http.MethodGet, "/health", nil)
-
-	h := Handlers{
-		Service:         nil,
-		DefaultDistance: 0,
-		Cities:          nil,
-		Logger:          nil,
+	tests := []struct {
+		name           string
+		circuitBreaker circuitBreaker
+		want           string
+	}{
+		{
+			"Given no circuit breaker is configured then status is returned",
+			nil,
+			`{"status":"up"}`,
+		},
+		{
+			"Given a circuit breaker is configured then status and circuit breaker state are returned",
+			mockCircuitBreaker{dwp.CircuitOpen},
+			`{"status":"up","circuitBreaker":"open"}`,
+		},
 	}
 
-	h.Health(w, r)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/health", nil)
+
+			h := Handlers{
+				Service:         nil,
+				DefaultDistance: 0,
+				Cities:          nil,
+				CircuitBreaker:  tt.circuitBreaker,
+				Logger:          nil,
+			}
 
-	resp := w.Result()
+			h.Health(w, r)
 
-	defer resp.Body.Close()
+			resp := w.Result()
 
-	if resp.StatusCode != http.StatusNoContent {
-		t.Errorf("Health() = %v, want %v", w.Code, http.StatusNoContent)
+			defer resp.Body.Close()
+
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("Health() = %v, want %v", resp.StatusCode, http.StatusOK)
+			}
+
+			if resp.Header.Get("Content-Type") != ContentTypeApplicationJSON {
+				t.Errorf("Health() = %v, want %v", resp.Header.Get("Content-Type"), ContentTypeApplicationJSON)
+			}
+
+			b, _ := io.ReadAll(resp.Body)
+
+			if body := strings.TrimSpace(string(b)); body != tt.want {
+				t.Errorf("Health() = %v, want %v", body, tt.want)
+			}
+		})
 	}
 }
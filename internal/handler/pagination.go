@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// page is the window of results requested with the limit and offset queries.
+type page struct {
+	limit  int
+	offset int
+}
+
+// parsePage validates the limit and offset queries. The limit defaults to DefaultPageSize and may not exceed
+// MaxPageSize. A MaxPageSize of zero means there is no maximum.
+func (h Handlers) parsePage(v *validator, query url.Values) page {
+	maximum := h.MaxPageSize
+	if maximum == 0 {
+		maximum = math.MaxInt
+	}
+
+	defaultLimit := h.DefaultPageSize
+	if defaultLimit == 0 || defaultLimit > maximum {
+		defaultLimit = maximum
+	}
+
+	return page{
+		limit:  v.integer(query, "limit", defaultLimit, 1, maximum),
+		offset: v.integer(query, "offset", 0, 0, math.MaxInt),
+	}
+}
+
+// paginate returns the page of items. The total number of items is written to the X-Total-Count header, and links to
+// the first, last, previous and next pages to the Link header.
+func paginate[T any](w http.ResponseWriter, r *http.Request, items []T, p page) []T {
+	total := len(items)
+
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	w.Header().Set("Link", pageLinks(r.URL, total, p))
+
+	if p.offset >= total {
+		return []T{}
+	}
+
+	end := total
+	if p.limit < total-p.offset {
+		end = p.offset + p.limit
+	}
+
+	return items[p.offset:end]
+}
+
+// pageLinks returns the Link header value for the page, preserving every other query of the request URL.
+func pageLinks(u *url.URL, total int, p page) string {
+	last := 0
+	if total > 0 {
+		last = (total - 1) / p.limit * p.limit
+	}
+
+	links := []string{
+		pageLink(u, p.limit, 0, "first"),
+		pageLink(u, p.limit, last, "last"),
+	}
+
+	if p.offset > 0 {
+		prev := p.offset - p.limit
+		if prev < 0 {
+			prev = 0
+		}
+
+		links = append(links, pageLink(u, p.limit, prev, "prev"))
+	}
+
+	if p.offset < total-p.limit {
+		links = append(links, pageLink(u, p.limit, p.offset+p.limit, "next"))
+	}
+
+	return strings.Join(links, ", ")
+}
+
+func pageLink(u *url.URL, limit int, offset int, rel string) string {
+	query := u.Query()
+	query.Set("limit", strconv.Itoa(limit))
+	query.Set("offset", strconv.Itoa(offset))
+
+	link := url.URL{Path: u.Path, RawQuery: query.Encode()}
+
+	return fmt.Sprintf("<%s>; rel=%q", link.String(), rel)
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func Test_paginate(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+
+	tests := []struct {
+		name  string
+		page  page
+		want  []int
+		links string
+	}{
+		{
+			"first page",
+			page{limit: 2, offset: 0},
+			[]int{1, 2},
+			`</api/people?limit=2&offset=0&sort=distance>; rel="first", </api/people?limit=2&offset=4&sort=distance>; rel="last", </api/people?limit=2&offset=2&sort=distance>; rel="next"`,
+		},
+		{
+			"middle page",
+			page{limit: 2, offset: 1},
+			[]int{2, 3},
+			`</api/people?limit=2&offset=0&sort=distance>; rel="first", </api/people?limit=2&offset=4&sort=distance>; rel="last", </api/people?limit=2&offset=0&sort=distance>; rel="prev", </api/people?limit=2&offset=3&sort=distance>; rel="next"`,
+		},
+		{
+			"last page",
+			page{limit: 2, offset: 4},
+			[]int{5},
+			`</api/people?limit=2&offset=0&sort=distance>; rel="first", </api/people?limit=2&offset=4&sort=distance>; rel="last", </api/people?limit=2&offset=2&sort=distance>; rel="prev"`,
+		},
+		{
+			"offset beyond the end",
+			page{limit: 2, offset: 10},
+			[]int{},
+			`</api/people?limit=2&offset=0&sort=distance>; rel="first", </api/people?limit=2&offset=4&sort=distance>; rel="last", </api/people?limit=2&offset=8&sort=distance>; rel="prev"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/api/people?sort=distance&limit=1", nil)
+
+			if got := paginate(w, r, items, tt.page); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("paginate() = %v, want %v", got, tt.want)
+			}
+
+			if got := w.Header().Get("X-Total-Count"); got != "5" {
+				t.Errorf("paginate() X-Total-Count = %v, want 5", got)
+			}
+
+			if got := w.Header().Get("Link"); got != tt.links {
+				t.Errorf("paginate() Link = %v, want %v", got, tt.links)
+			}
+		})
+	}
+}
+
+func TestHandlers_parsePage(t *testing.T) {
+	tests := []struct {
+		query   string
+		h       Handlers
+		want    page
+		invalid bool
+	}{
+		{"", Handlers{DefaultPageSize: 10, MaxPageSize: 100}, page{limit: 10, offset: 0}, false},
+		{"limit=50&offset=20", Handlers{DefaultPageSize: 10, MaxPageSize: 100}, page{limit: 50, offset: 20}, false},
+		{"limit=101", Handlers{DefaultPageSize: 10, MaxPageSize: 100}, page{limit: 10, offset: 0}, true},
+		{"limit=0", Handlers{DefaultPageSize: 10, MaxPageSize: 100}, page{limit: 10, offset: 0}, true},
+		{"offset=-1", Handlers{DefaultPageSize: 10, MaxPageSize: 100}, page{limit: 10, offset: 0}, true},
+		{"", Handlers{DefaultPageSize: 200, MaxPageSize: 100}, page{limit: 100, offset: 0}, false},
+	}
+
+	for _, tt := range tests {
+		query, _ := url.ParseQuery(tt.query)
+		v := validator{}
+
+		if got := tt.h.parsePage(&v, query); got != tt.want {
+			t.Errorf("parsePage(%s) = %v, want %v", tt.query, got, tt.want)
+		}
+
+		if v.valid() == tt.invalid {
+			t.Errorf("parsePage(%s) invalid = %v, want %v", tt.query, v.invalid, tt.invalid)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+)
+
+const ContentTypeApplicationProblemJSON = "application/problem+json"
+
+// problemDetails is an RFC 7807 problem details error response.
+type problemDetails struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail"`
+	Instance string `json:"instance"`
+	// RequestID identifies the request, as returned in the X-Request-ID header.
+	RequestID string    `json:"requestId,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+	// InvalidParams lists each invalid parameter of a bad request.
+	InvalidParams []invalidParameter `json:"invalid-params,omitempty"`
+}
+
+// errorMediaTypes are the media types accepted in the Accept header of a request that results in an error, mapped to
+// whether the error is returned as problem details.
+var errorMediaTypes = map[string]bool{
+	ContentTypeApplicationJSON:        false,
+	ContentTypeApplicationProblemJSON: true,
+}
+
+// acceptsProblem reports whether the error response to the request should be problem details rather than the legacy
+// error response, which is returned unless problem details are preferred in the Accept header.
+func acceptsProblem(r *http.Request) bool {
+	return negotiate(r, errorMediaTypes, false)
+}
+
+// newProblemDetails converts the legacy error response to problem details. As the problem types are not documented
+// beyond their status code, the type is about:blank and the title is the status text.
+func newProblemDetails(response errorResponse) problemDetails {
+	return problemDetails{
+		Type:          "about:blank",
+		Title:         http.StatusText(response.Status),
+		Status:        response.Status,
+		Detail:        response.Message,
+		Instance:      response.Path,
+		RequestID:     response.RequestID,
+		Timestamp:     response.Timestamp,
+		InvalidParams: response.Errors,
+	}
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/J-R-Oliver/dwp-assessment-go/pkg/logging"
+	"github.com/J-R-Oliver/dwp-assessment-go/pkg/requestid"
+)
+
+func Test_acceptsProblem(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   bool
+	}{
+		{"", false},
+		{"*/*", false},
+		{"application/json", false},
+		{"application/problem+json", true},
+		{"application/json, application/problem+json", false},
+		{"application/json;q=0.5, application/problem+json", true},
+		{"text/csv, application/problem+json;q=0.1", true},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/path", nil)
+		r.Header.Set("Accept", tt.accept)
+
+		if got := acceptsProblem(r); got != tt.want {
+			t.Errorf("acceptsProblem(%q) = %v, want %v", tt.accept, got, tt.want)
+		}
+	}
+}
+
+func TestHandlers_invalidParameters_problem(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/api/people?limit=a", nil)
+	r.Header.Set("Accept", "application/problem+json")
+	r = r.WithContext(requestid.NewContext(r.Context(), "a1b2c3"))
+
+	h := Handlers{Logger: logging.New(logging.Info)}
+
+	invalid := []invalidParameter{{Parameter: "limit", Reason: "a is not an integer"}}
+
+	h.invalidParameters(w, r, invalid)
+
+	resp := w.Result()
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("invalidParameters() = %v, want %v", resp.StatusCode, http.StatusBadRequest)
+	}
+
+	if resp.Header.Get("Content-Type") != ContentTypeApplicationProblemJSON {
+		t.Errorf("invalidParameters() = %v, want application/problem+json", resp.Header.Get("Content-Type"))
+	}
+
+	var got problemDetails
+
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatalf("invalidParameters() error decoding body = %v", err)
+	}
+
+	want := problemDetails{
+		Type:          "about:blank",
+		Title:         "Bad Request",
+		Status:        http.StatusBadRequest,
+		Detail:        "Invalid Query Parameters",
+		Instance:      "/api/people",
+		RequestID:     "a1b2c3",
+		Timestamp:     got.Timestamp,
+		InvalidParams: invalid,
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("invalidParameters() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+)
+
+// invalidParameter describes a request parameter that failed validation and why.
+type invalidParameter struct {
+	Parameter string `json:"parameter"`
+	Reason    string `json:"reason"`
+}
+
+// validator collects every invalid parameter of a request so that they can be reported together, rather than only the
+// first one found.
+type validator struct {
+	invalid []invalidParameter
+}
+
+func (v *validator) reject(parameter string, reason string) {
+	v.invalid = append(v.invalid, invalidParameter{Parameter: parameter, Reason: reason})
+}
+
+func (v *validator) valid() bool {
+	return len(v.invalid) == 0
+}
+
+// coordinate parses the required query name as a number between -maximum and maximum.
+func (v *validator) coordinate(query url.Values, name string, maximum float64) float64 {
+	value := query.Get(name)
+	if value == "" {
+		v.reject(name, "is required")
+		return 0
+	}
+
+	coordinate, err := strconv.ParseFloat(value, bitSize)
+	if err != nil || math.IsNaN(coordinate) || coordinate < -maximum || coordinate > maximum {
+		v.reject(name, fmt.Sprintf("%s is not a number between %g and %g", value, -maximum, maximum))
+		return 0
+	}
+
+	return coordinate
+}
+
+// integer parses the optional query name as an integer between minimum and maximum, returning defaultValue if it is
+// absent.
+func (v *validator) integer(query url.Values, name string, defaultValue int, minimum int, maximum int) int {
+	value := query.Get(name)
+	if value == "" {
+		return defaultValue
+	}
+
+	i, err := strconv.Atoi(value)
+
+	switch {
+	case err != nil:
+		v.reject(name, fmt.Sprintf("%s is not an integer", value))
+	case i < minimum:
+		v.reject(name, fmt.Sprintf("%d is less than the minimum of %d", i, minimum))
+	case i > maximum:
+		v.reject(name, fmt.Sprintf("%d is greater than the maximum of %d", i, maximum))
+	default:
+		return i
+	}
+
+	return defaultValue
+}
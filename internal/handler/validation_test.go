@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func Test_validator_coordinate(t *testing.T) {
+	tests := []struct {
+		query   string
+		want    float64
+		invalid []invalidParameter
+	}{
+		{"lat=51.5", 51.5, nil},
+		{"lat=-90", -90, nil},
+		{"", 0, []invalidParameter{{"lat", "is required"}}},
+		{"lat=NaN", 0, []invalidParameter{{"lat", "NaN is not a number between -90 and 90"}}},
+		{"lat=-90.1", 0, []invalidParameter{{"lat", "-90.1 is not a number between -90 and 90"}}},
+	}
+
+	for _, tt := range tests {
+		query, _ := url.ParseQuery(tt.query)
+		v := validator{}
+
+		if got := v.coordinate(query, "lat", maxLatitude); got != tt.want {
+			t.Errorf("coordinate(%s) = %v, want %v", tt.query, got, tt.want)
+		}
+
+		if !reflect.DeepEqual(v.invalid, tt.invalid) {
+			t.Errorf("coordinate(%s) invalid = %v, want %v", tt.query, v.invalid, tt.invalid)
+		}
+	}
+}
+
+func Test_validator_integer(t *testing.T) {
+	tests := []struct {
+		query   string
+		want    int
+		invalid []invalidParameter
+	}{
+		{"distance=25", 25, nil},
+		{"", 50, nil},
+		{"distance=1.5", 50, []invalidParameter{{"distance", "1.5 is not an integer"}}},
+		{"distance=0", 50, []invalidParameter{{"distance", "0 is less than the minimum of 1"}}},
+		{"distance=101", 50, []invalidParameter{{"distance", "101 is greater than the maximum of 100"}}},
+	}
+
+	for _, tt := range tests {
+		query, _ := url.ParseQuery(tt.query)
+		v := validator{}
+
+		if got := v.integer(query, "distance", 50, 1, 100); got != tt.want {
+			t.Errorf("integer(%s) = %v, want %v", tt.query, got, tt.want)
+		}
+
+		if v.valid() != (tt.invalid == nil) || !reflect.DeepEqual(v.invalid, tt.invalid) {
+			t.Errorf("integer(%s) invalid = %v, want %v", tt.query, v.invalid, tt.invalid)
+		}
+	}
+}
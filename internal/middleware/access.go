@@ -0,0 +1,205 @@
+package middleware
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/J-R-Oliver/dwp-assessment-go/pkg/logging"
+)
+
+// commonLogTime is the layout of the time in the Common Log Format.
+const commonLogTime = "02/Jan/2006:15:04:05 -0700"
+
+// AccessLogFormat is the format in which AccessLogHandler logs requests.
+type AccessLogFormat int
+
+const (
+	// AccessLogCommon logs each request in the Common Log Format, followed by the latency in microseconds.
+	AccessLogCommon AccessLogFormat = iota
+	// AccessLogCombined logs each request in the Combined Log Format, which adds the referer and user agent to the
+	// Common Log Format, followed by the latency in microseconds.
+	AccessLogCombined
+	// AccessLogJSON logs each request as fields of a log entry of the Logger, which is only written as a JSON object
+	// by the JSON logging format.
+	AccessLogJSON
+)
+
+func (f AccessLogFormat) String() string {
+	switch f {
+	case AccessLogCommon:
+		return "common"
+	case AccessLogCombined:
+		return "combined"
+	case AccessLogJSON:
+		return "json"
+	}
+
+	return ""
+}
+
+// ParseAccessLogFormat parses an access log format. Valid options are common, combined or json.
+func ParseAccessLogFormat(s string) (AccessLogFormat, error) {
+	switch s {
+	case "common":
+		return AccessLogCommon, nil
+	case "combined":
+		return AccessLogCombined, nil
+	case "json":
+		return AccessLogJSON, nil
+	}
+
+	return 0, fmt.Errorf("%s is not a valid access log format - valid options are common, combined or json", s)
+}
+
+// AccessLogSettings configures AccessLogHandler.
+type AccessLogSettings struct {
+	Format AccessLogFormat
+	// SkipPaths are the paths of requests which are not logged, such as /health.
+	SkipPaths []string
+	// Writer is where requests are written in the common and combined formats, one line per request without a level
+	// or fields. Defaults to Stdout.
+	Writer io.Writer
+}
+
+// responseRecorder records the status code and number of bytes of the response written through it.
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += n
+
+	return n, err
+}
+
+// Flush flushes the underlying ResponseWriter if it supports flushing, so that streamed responses are not buffered.
+func (r *responseRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		if r.status == 0 {
+			r.status = http.StatusOK
+		}
+
+		f.Flush()
+	}
+}
+
+// Unwrap returns the underlying ResponseWriter, for use by http.ResponseController.
+func (r *responseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// AccessLogHandler logs each request once its response has been written, with the response's status code, size and
+// latency, unless the request's path is one of the settings' SkipPaths. Requests are logged when the Logger's info
+// level is enabled. In the common and combined formats, each request is written as a line to the settings' Writer; in
+// the json format, it is logged by the Logger carried by the request's context so that the log entry includes the
+// request ID.
+func AccessLogHandler(next http.Handler, logger logging.Logger, settings AccessLogSettings) http.Handler {
+	writer := settings.Writer
+	if writer == nil {
+		writer = os.Stdout
+	}
+
+	out := log.New(writer, "", 0)
+
+	skip := make(map[string]bool, len(settings.SkipPaths))
+
+	for _, path := range settings.SkipPaths {
+		skip[path] = true
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if skip[r.URL.Path] {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		start := time.Now()
+		recorder := &responseRecorder{ResponseWriter: w}
+
+		next.ServeHTTP(recorder, r)
+
+		if recorder.status == 0 {
+			recorder.status = http.StatusOK
+		}
+
+		if !logger.Enabled(logging.Info) {
+			return
+		}
+
+		latency := time.Since(start)
+
+		switch settings.Format {
+		case AccessLogJSON:
+			logging.FromContext(r.Context(), logger).With(logging.Fields{
+				"remoteAddr": remoteHost(r),
+				"method":     r.Method,
+				"uri":        r.URL.RequestURI(),
+				"protocol":   r.Proto,
+				"status":     recorder.status,
+				"bytes":      recorder.bytes,
+				"latencyMs":  latency.Milliseconds(),
+				"referer":    r.Referer(),
+				"userAgent":  r.UserAgent(),
+			}).Info("request completed")
+		case AccessLogCombined:
+			out.Printf("%s %d", combinedLogLine(r, start, recorder), latency.Microseconds())
+		default:
+			out.Printf("%s %d", commonLogLine(r, start, recorder), latency.Microseconds())
+		}
+	})
+}
+
+// commonLogLine returns the request in the Common Log Format. The identity and user of the client are not known.
+func commonLogLine(r *http.Request, start time.Time, recorder *responseRecorder) string {
+	bytes := "-"
+
+	if recorder.bytes > 0 {
+		bytes = fmt.Sprint(recorder.bytes)
+	}
+
+	return fmt.Sprintf(`%s - - [%s] "%s %s %s" %d %s`,
+		remoteHost(r), start.Format(commonLogTime), r.Method, r.URL.RequestURI(), r.Proto, recorder.status, bytes)
+}
+
+// combinedLogLine returns the request in the Combined Log Format.
+func combinedLogLine(r *http.Request, start time.Time, recorder *responseRecorder) string {
+	return fmt.Sprintf(`%s %q %q`, commonLogLine(r, start, recorder), orDash(r.Referer()), orDash(r.UserAgent()))
+}
+
+// remoteHost returns the host of the request's remote address, without its port.
+func remoteHost(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+
+	return s
+}
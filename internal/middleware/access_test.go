@@ -0,0 +1,227 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/J-R-Oliver/dwp-assessment-go/pkg/logging"
+	"github.com/J-R-Oliver/dwp-assessment-go/pkg/requestid"
+)
+
+// captureStdout returns everything written to os.Stdout by f.
+func captureStdout(f func()) string {
+	r, w, _ := os.Pipe()
+	stdout := os.Stdout
+	os.Stdout = w
+
+	f()
+
+	w.Close()
+
+	os.Stdout = stdout
+
+	out, _ := io.ReadAll(r)
+
+	return string(out)
+}
+
+func TestParseAccessLogFormat(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    AccessLogFormat
+		wantErr bool
+	}{
+		{"common", AccessLogCommon, false},
+		{"combined", AccessLogCombined, false},
+		{"json", AccessLogJSON, false},
+		{"apache", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseAccessLogFormat(tt.s)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseAccessLogFormat(%q) error = %v, wantErr %v", tt.s, err, tt.wantErr)
+		}
+
+		if got != tt.want {
+			t.Errorf("ParseAccessLogFormat(%q) = %v, want %v", tt.s, got, tt.want)
+		}
+
+		if !tt.wantErr && got.String() != tt.s {
+			t.Errorf("String() = %v, want %v", got.String(), tt.s)
+		}
+	}
+}
+
+func TestAccessLogHandler(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("not found")) //nolint:errcheck
+	})
+
+	newRequest := func(path string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		r.RemoteAddr = "192.0.2.1:1234"
+		r.Header.Set("User-Agent", "test-agent")
+		r.Header.Set("Referer", "http://example.com/")
+
+		return r
+	}
+
+	t.Run("Given common format then request is written in the Common Log Format", func(t *testing.T) {
+		var out strings.Builder
+
+		h := AccessLogHandler(next, logging.New(logging.Info), AccessLogSettings{Format: AccessLogCommon, Writer: &out})
+		h.ServeHTTP(httptest.NewRecorder(), newRequest("/api/people?limit=1"))
+
+		want := regexp.MustCompile(`^192\.0\.2\.1 - - \[\d{2}/\w{3}/\d{4}:\d{2}:\d{2}:\d{2} [+-]\d{4}] "GET /api/people\?limit=1 HTTP/1\.1" 404 9 \d+\n$`)
+
+		if !want.MatchString(out.String()) {
+			t.Errorf("AccessLogHandler() = %v, want match for %v", out.String(), want)
+		}
+	})
+
+	t.Run("Given combined format then request is written with referer and user agent", func(t *testing.T) {
+		var out strings.Builder
+
+		h := AccessLogHandler(next, logging.New(logging.Info), AccessLogSettings{Format: AccessLogCombined, Writer: &out})
+		h.ServeHTTP(httptest.NewRecorder(), newRequest("/api/people"))
+
+		want := regexp.MustCompile(`^192\.0\.2\.1 - - \[[^]]+] "GET /api/people HTTP/1\.1" 404 9 "http://example\.com/" "test-agent" \d+\n$`)
+
+		if !want.MatchString(out.String()) {
+			t.Errorf("AccessLogHandler() = %v, want match for %v", out.String(), want)
+		}
+	})
+
+	t.Run("Given the access level is not enabled then request is not written", func(t *testing.T) {
+		var out strings.Builder
+
+		h := AccessLogHandler(next, logging.New(logging.Error), AccessLogSettings{Writer: &out})
+		h.ServeHTTP(httptest.NewRecorder(), newRequest("/api/people"))
+
+		if out.String() != "" {
+			t.Errorf("AccessLogHandler() = %v, want nothing written", out.String())
+		}
+	})
+
+	t.Run("Given json format then request is logged as fields with the request ID", func(t *testing.T) {
+		s := captureStdout(func() {
+			l := logging.New(logging.Info, logging.WithFormat(logging.FormatJSON))
+			r := newRequest("/api/people")
+			r = r.WithContext(logging.NewContext(r.Context(), logging.Fields{"requestId": "a1b2c3"}))
+
+			h := AccessLogHandler(next, l, AccessLogSettings{Format: AccessLogJSON})
+			h.ServeHTTP(httptest.NewRecorder(), r)
+		})
+
+		lines := strings.Split(strings.TrimSpace(s), "\n")
+
+		var got map[string]any
+
+		if err := json.Unmarshal([]byte(lines[len(lines)-1]), &got); err != nil {
+			t.Fatalf("AccessLogHandler() = %v, want JSON: %v", s, err)
+		}
+
+		want := map[string]any{
+			"message":    "request completed",
+			"remoteAddr": "192.0.2.1",
+			"method":     "GET",
+			"uri":        "/api/people",
+			"protocol":   "HTTP/1.1",
+			"status":     404.0,
+			"bytes":      9.0,
+			"referer":    "http://example.com/",
+			"userAgent":  "test-agent",
+			"requestId":  "a1b2c3",
+		}
+
+		for k, v := range want {
+			if got[k] != v {
+				t.Errorf("AccessLogHandler() %s = %v, want %v", k, got[k], v)
+			}
+		}
+
+		if _, ok := got["latencyMs"]; !ok {
+			t.Errorf("AccessLogHandler() = %v, want latencyMs", got)
+		}
+	})
+
+	t.Run("Given a skipped path then request is not logged", func(t *testing.T) {
+		var out strings.Builder
+
+		h := AccessLogHandler(next, logging.New(logging.Info), AccessLogSettings{SkipPaths: []string{"/health"}, Writer: &out})
+		h.ServeHTTP(httptest.NewRecorder(), newRequest("/health"))
+
+		if out.String() != "" {
+			t.Errorf("AccessLogHandler() = %v, want nothing logged", out.String())
+		}
+	})
+
+	t.Run("Given a handler that does not write then status is logged as 200", func(t *testing.T) {
+		var out strings.Builder
+
+		h := AccessLogHandler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}), logging.New(logging.Info), AccessLogSettings{Writer: &out})
+		h.ServeHTTP(httptest.NewRecorder(), newRequest("/api/people"))
+
+		if !strings.Contains(out.String(), `HTTP/1.1" 200 -`) {
+			t.Errorf("AccessLogHandler() = %v, want 200 -", out.String())
+		}
+	})
+
+	t.Run("Given no writer then request is written to Stdout", func(t *testing.T) {
+		s := captureStdout(func() {
+			h := AccessLogHandler(next, logging.New(logging.Info), AccessLogSettings{})
+			h.ServeHTTP(httptest.NewRecorder(), newRequest("/api/people"))
+		})
+
+		if !strings.Contains(s, `"GET /api/people HTTP/1.1" 404 9`) {
+			t.Errorf("AccessLogHandler() = %v, want request written", s)
+		}
+	})
+}
+
+func Test_responseRecorder_Flush(t *testing.T) {
+	response := httptest.NewRecorder()
+	recorder := &responseRecorder{ResponseWriter: response}
+
+	var w http.ResponseWriter = recorder
+
+	f, ok := w.(http.Flusher)
+	if !ok {
+		t.Fatalf("responseRecorder does not implement http.Flusher")
+	}
+
+	w.Write([]byte("{}\n")) //nolint:errcheck
+	f.Flush()
+
+	if !response.Flushed {
+		t.Errorf("Flush() did not flush the underlying ResponseWriter")
+	}
+
+	if recorder.status != http.StatusOK || recorder.bytes != 3 {
+		t.Errorf("responseRecorder = %v %v, want 200 3", recorder.status, recorder.bytes)
+	}
+}
+
+func Test_requestIDHandler_accessLog(t *testing.T) {
+	s := captureStdout(func() {
+		l := logging.New(logging.Info)
+		h := RequestIDHandler(AccessLogHandler(http.NotFoundHandler(), l, AccessLogSettings{Format: AccessLogJSON}), l)
+
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set(requestid.Header, "a1b2c3")
+
+		h.ServeHTTP(httptest.NewRecorder(), r)
+	})
+
+	if !strings.Contains(s, "requestId=a1b2c3") {
+		t.Errorf("AccessLogHandler() = %v, want requestId=a1b2c3", s)
+	}
+}
@@ -2,10 +2,10 @@ package middleware
 
 import (
 	"errors"
-	"fmt"
 	"net/http"
 
 	"github.com/J-R-Oliver/dwp-assessment-go/pkg/logging"
+	"github.com/J-R-Oliver/dwp-assessment-go/pkg/requestid"
 )
 
 func PanicHandler(next http.Handler, internalServerErrorHandler func(http.ResponseWriter, *http.Request, error)) http.Handler {
@@ -20,9 +20,30 @@ func PanicHandler(next http.Handler, internalServerErrorHandler func(http.Respon
 	})
 }
 
-func LogRequestHandler(next http.Handler, logger logging.Logger) http.Handler {
+// RequestIDHandler identifies each request by the request ID in its X-Request-ID header, or by a generated request ID if
+// the header is absent or invalid. The request ID is returned in the X-Request-ID header of the response, and the
+// request's context carries the request ID, which is added to the Loggers returned by logging.FromContext.
+func RequestIDHandler(next http.Handler, logger logging.Logger) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		logger.Info(fmt.Sprintf("%s - %s %s %s", r.RemoteAddr, r.Proto, r.Method, r.URL.RequestURI()))
-		next.ServeHTTP(w, r)
+		id := r.Header.Get(requestid.Header)
+
+		if !requestid.Valid(id) {
+			var err error
+
+			id, err = requestid.New()
+			if err != nil {
+				logger.Error(err)
+				next.ServeHTTP(w, r)
+
+				return
+			}
+		}
+
+		w.Header().Set(requestid.Header, id)
+
+		ctx := requestid.NewContext(r.Context(), id)
+		ctx = logging.NewContext(ctx, logging.Fields{"requestId": id})
+
+		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
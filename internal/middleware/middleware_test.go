@@ -1,14 +1,12 @@
 package middleware
 
 import (
-	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
-	"os"
-	"strings"
 	"testing"
 
 	"github.com/J-R-Oliver/dwp-assessment-go/pkg/logging"
+	"github.com/J-R-Oliver/dwp-assessment-go/pkg/requestid"
 )
 
 var mockNext func(w http.ResponseWriter, r *http.Request)
@@ -25,11 +23,11 @@ func TestPanicHandler(t *testing.T) {
 
 	mockNext = func(w http.ResponseWriter, r *http.Request) {
 		if w != response {
-			t.Errorf("LogRequestHandler() ResponseWriter = %v, want %v", w, response)
+			t.Errorf("PanicHandler() ResponseWriter = %v, want %v", w, response)
 		}
 
 		if r != request {
-			t.Errorf("LogRequestHandler() Requetss = %v, want %v", r, request)
+			t.Errorf("PanicHandler() Requetss = %v, want %v", r, request)
 		}
 
 		panic("test panic")
@@ -46,34 +44,47 @@ func TestPanicHandler(t *testing.T) {
 	h.ServeHTTP(response, request)
 }
 
-func TestLogRequestHandler(t *testing.T) {
-	r, w, _ := os.Pipe()
-	os.Stdout = w
+func TestRequestIDHandler(t *testing.T) {
+	tests := []struct {
+		name      string
+		requestID string
+		wantSame  bool
+	}{
+		{"Given a request with an X-Request-ID header then the request ID is used", "test-request-id", true},
+		{"Given a request without an X-Request-ID header then a request ID is generated", "", false},
+		{"Given a request with an invalid X-Request-ID header then a request ID is generated", "test request id", false},
+	}
 
-	response := httptest.NewRecorder()
-	request := httptest.NewRequest(http.MethodGet, "/health", nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodGet, "/health", nil)
 
-	mockNext = func(w http.ResponseWriter, r *http.Request) {
-		if w != response {
-			t.Errorf("LogRequestHandler() ResponseWriter = %v, want %v", w, response)
-		}
+			if tt.requestID != "" {
+				request.Header.Set(requestid.Header, tt.requestID)
+			}
 
-		if r != request {
-			t.Errorf("LogRequestHandler() Requetss = %v, want %v", r, request)
-		}
-	}
+			var got string
 
-	l := logging.New(logging.Info)
-	h := LogRequestHandler(mock{}, l)
+			mockNext = func(w http.ResponseWriter, r *http.Request) {
+				got, _ = requestid.FromContext(r.Context())
+			}
 
-	h.ServeHTTP(response, request)
+			h := RequestIDHandler(mock{}, logging.New(logging.Info))
+
+			h.ServeHTTP(response, request)
 
-	w.Close()
+			if tt.wantSame && got != tt.requestID {
+				t.Errorf("RequestIDHandler() request ID = %v, want %v", got, tt.requestID)
+			}
 
-	out, _ := ioutil.ReadAll(r)
-	s := string(out)
+			if !tt.wantSame && (got == tt.requestID || !requestid.Valid(got)) {
+				t.Errorf("RequestIDHandler() request ID = %v, want generated request ID", got)
+			}
 
-	if !strings.Contains(s, "HTTP/1.1 GET /health") {
-		t.Errorf("LogRequestHandler() = %v, want HTTP/1.1 GET /health", s)
+			if header := response.Header().Get(requestid.Header); header != got {
+				t.Errorf("RequestIDHandler() X-Request-ID = %v, want %v", header, got)
+			}
+		})
 	}
 }
@@ -0,0 +1,90 @@
+package people
+
+import (
+	"net"
+	"strings"
+
+	"github.com/J-R-Oliver/dwp-assessment-go/pkg/dwp"
+)
+
+// Filter narrows people by their attributes. Every non-zero condition must match, so the zero value matches everyone.
+type Filter struct {
+	// FirstName and LastName match names exactly.
+	FirstName string
+	LastName  string
+	// FirstNamePrefix and LastNamePrefix match names starting with the prefix.
+	FirstNamePrefix string
+	LastNamePrefix  string
+	// FirstNameContains and LastNameContains match names containing the value, ignoring case.
+	FirstNameContains string
+	LastNameContains  string
+	// EmailDomain matches email addresses at the domain, ignoring case.
+	EmailDomain string
+	// IPNetwork matches IP addresses within the network.
+	IPNetwork *net.IPNet
+	// MinID and MaxID match IDs within the inclusive range. A MaxID of zero means there is no maximum.
+	MinID int
+	MaxID int
+}
+
+// Matches reports whether the person matches every condition of the filter.
+func (f Filter) Matches(person dwp.Person) bool {
+	return matchName(person.FirstName, f.FirstName, f.FirstNamePrefix, f.FirstNameContains) &&
+		matchName(person.LastName, f.LastName, f.LastNamePrefix, f.LastNameContains) &&
+		matchEmailDomain(person.Email, f.EmailDomain) &&
+		(f.IPNetwork == nil || f.IPNetwork.Contains(net.ParseIP(person.IPAddress))) &&
+		person.ID >= f.MinID &&
+		(f.MaxID == 0 || person.ID <= f.MaxID)
+}
+
+// apply returns the people matching the filter.
+func (f Filter) apply(people dwp.People) dwp.People {
+	if f == (Filter{}) {
+		return people
+	}
+
+	filtered := dwp.People{}
+
+	for _, person := range people {
+		if f.Matches(person) {
+			filtered = append(filtered, person)
+		}
+	}
+
+	return filtered
+}
+
+func matchName(name string, exact string, prefix string, contains string) bool {
+	return (exact == "" || name == exact) &&
+		strings.HasPrefix(name, prefix) &&
+		strings.Contains(strings.ToLower(name), strings.ToLower(contains))
+}
+
+func matchEmailDomain(email string, domain string) bool {
+	if domain == "" {
+		return true
+	}
+
+	i := strings.LastIndex(email, "@")
+
+	return i >= 0 && strings.EqualFold(email[i+1:], domain)
+}
+
+// ParseIPNetwork parses an IP address, which matches only itself, or a CIDR range such as 192.168.0.0/16.
+func ParseIPNetwork(s string) (*net.IPNet, error) {
+	if strings.Contains(s, "/") {
+		_, network, err := net.ParseCIDR(s)
+		return network, err
+	}
+
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return nil, &net.ParseError{Type: "IP address", Text: s}
+	}
+
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(len(ip)*8, len(ip)*8)}, nil
+}
@@ -0,0 +1,78 @@
+package people
+
+import (
+	"testing"
+
+	"github.com/J-R-Oliver/dwp-assessment-go/pkg/dwp"
+)
+
+func TestFilter_Matches(t *testing.T) {
+	person := dwp.Person{
+		ID:        2,
+		FirstName: "Bendix",
+		LastName:  "Halgarth",
+		Email:     "bhalgarth1@timesonline.co.uk",
+		IPAddress: "4.185.73.82",
+	}
+
+	network, _ := ParseIPNetwork("4.185.0.0/16")
+	otherNetwork, _ := ParseIPNetwork("4.186.0.0/16")
+
+	tests := []struct {
+		name string
+		f    Filter
+		want bool
+	}{
+		{"zero value", Filter{}, true},
+		{"exact first name", Filter{FirstName: "Bendix"}, true},
+		{"exact first name with different case", Filter{FirstName: "bendix"}, false},
+		{"last name prefix", Filter{LastNamePrefix: "Hal"}, true},
+		{"last name prefix not matching", Filter{LastNamePrefix: "garth"}, false},
+		{"first name contains ignoring case", Filter{FirstNameContains: "DIX"}, true},
+		{"last name contains not matching", Filter{LastNameContains: "smith"}, false},
+		{"email domain ignoring case", Filter{EmailDomain: "TimesOnline.co.uk"}, true},
+		{"email domain not matching", Filter{EmailDomain: "co.uk"}, false},
+		{"ip network", Filter{IPNetwork: network}, true},
+		{"ip network not matching", Filter{IPNetwork: otherNetwork}, false},
+		{"id range", Filter{MinID: 2, MaxID: 2}, true},
+		{"id below minimum", Filter{MinID: 3}, false},
+		{"id above maximum", Filter{MaxID: 1}, false},
+		{"several conditions", Filter{FirstNamePrefix: "Ben", EmailDomain: "timesonline.co.uk", MinID: 1}, true},
+		{"several conditions with one not matching", Filter{FirstNamePrefix: "Ben", EmailDomain: "squidoo.com"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.f.Matches(person); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseIPNetwork(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    string
+		wantErr bool
+	}{
+		{"4.185.73.82", "4.185.73.82/32", false},
+		{"4.185.73.82/16", "4.185.0.0/16", false},
+		{"2001:db8::1", "2001:db8::1/128", false},
+		{"4.185.73", "", true},
+		{"4.185.73.82/33", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseIPNetwork(tt.s)
+
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseIPNetwork(%s) error = %v, wantErr %v", tt.s, err, tt.wantErr)
+			continue
+		}
+
+		if err == nil && got.String() != tt.want {
+			t.Errorf("ParseIPNetwork(%s) = %v, want %v", tt.s, got, tt.want)
+		}
+	}
+}
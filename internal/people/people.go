@@ -3,6 +3,7 @@ package people
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/J-R-Oliver/dwp-assessment-go/pkg/dwp"
 	"github.com/J-R-Oliver/dwp-assessment-go/pkg/logging"
@@ -13,6 +14,7 @@ import (
 type peopleClient interface {
 	RetrievePeople(ctx context.Context) (dwp.People, error)
 	RetrievePeopleByCity(ctx context.Context, city string) (dwp.People, error)
+	RetrievePerson(ctx context.Context, id int) (dwp.Person, error)
 }
 
 type Service struct {
@@ -21,82 +23,188 @@ type Service struct {
 	Logger    logging.Logger
 }
 
-func (s Service) RetrievePeople(ctx context.Context) (dwp.People, error) {
-	s.Logger.Info("Attempting to retrieve all people")
+// logger returns s.Logger with the fields carried by ctx, which identify the request being served.
+func (s Service) logger(ctx context.Context) logging.Logger {
+	return logging.FromContext(ctx, s.Logger)
+}
+
+// RetrievePeople returns all people matching the filter, ordered by ID.
+func (s Service) RetrievePeople(ctx context.Context, f Filter) (dwp.People, error) {
+	s.logger(ctx).Info("Attempting to retrieve all people")
 
 	people, err := s.DwpClient.RetrievePeople(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	s.Logger.Info("All people retrieved successfully")
+	s.logger(ctx).Info("All people retrieved successfully")
+
+	people = f.apply(people)
+
+	sort.SliceStable(people, func(i, j int) bool {
+		return people[i].ID < people[j].ID
+	})
 
 	return people, nil
 }
 
-func (s Service) RetrievePeopleByCity(ctx context.Context, city string, distance int) (dwp.People, error) {
+// RetrievePerson returns the person with the ID. If the person does not exist then the error matches dwp.ErrNotFound.
+func (s Service) RetrievePerson(ctx context.Context, id int) (dwp.Person, error) {
+	s.logger(ctx).With(logging.Fields{"id": id}).Info("Attempting to retrieve person")
+
+	person, err := s.DwpClient.RetrievePerson(ctx, id)
+	if err != nil {
+		return dwp.Person{}, err
+	}
+
+	s.logger(ctx).Info("Person retrieved successfully")
+
+	return person, nil
+}
+
+// RetrievePeopleByCity returns the people registered in the city together with the people within the query's distance
+// of it, narrowed by the query's filter. Each person is returned once, with their distance from the city, marked with
+// whether they matched by city, proximity or both, and ordered by the query's sort.
+func (s Service) RetrievePeopleByCity(ctx context.Context, city string, q Query) (People, error) {
 	cityCoordinates, ok := s.Cities[city]
 	if !ok {
 		return nil, fmt.Errorf("%s's coordinates have not been configured", city)
 	}
 
-	c := make(chan dwp.People, 2) //nolint:gomnd
+	var nearbyPeople, cityPeople People
 
 	eg, ctx := errgroup.WithContext(ctx)
 
 	eg.Go(func() error {
-		s.Logger.Info("Attempting to retrieve all people")
+		s.logger(ctx).Info("Attempting to retrieve all people")
 
 		people, err := s.DwpClient.RetrievePeople(ctx)
 		if err != nil {
 			return err
 		}
 
-		s.Logger.Info("All people retrieved successfully")
-		c <- filterPeople(people, distance, cityCoordinates)
+		s.logger(ctx).Info("All people retrieved successfully")
+		nearbyPeople = filterPeople(q.Filter.apply(people), q.Distance, q.Unit, cityCoordinates)
 
 		return nil
 	})
 
 	eg.Go(func() error {
-		s.Logger.Info("Attempting to retrieve people by city")
-		cityPeople, err := s.DwpClient.RetrievePeopleByCity(ctx, city)
+		s.logger(ctx).Info("Attempting to retrieve people by city")
 
-		s.Logger.Info("People by city retrieved successfully")
-		c <- cityPeople
+		people, err := s.DwpClient.RetrievePeopleByCity(ctx, city)
+		if err != nil {
+			return err
+		}
+
+		s.logger(ctx).Info("People by city retrieved successfully")
+		cityPeople = locatePeople(q.Filter.apply(people), q.Unit, cityCoordinates)
 
-		return err
+		return nil
 	})
 
 	if err := eg.Wait(); err != nil {
 		return nil, err
 	}
 
-	close(c)
+	merged := mergePeople(cityPeople, nearbyPeople)
+	sortPeople(merged, q.Sort)
+
+	return merged, nil
+}
+
+// RetrievePeopleNear returns the people within the query's distance of the coordinates, narrowed by the query's filter.
+// Each person is returned with their distance from the coordinates, marked as matching by proximity, and ordered by the
+// query's sort.
+func (s Service) RetrievePeopleNear(ctx context.Context, coordinates haversine.Coord, q Query) (People, error) {
+	s.logger(ctx).Info("Attempting to retrieve all people")
 
-	var allPeople dwp.People
-	for people := range c {
-		allPeople = append(allPeople, people...)
+	people, err := s.DwpClient.RetrievePeople(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	return allPeople, nil
+	s.logger(ctx).Info("All people retrieved successfully")
+
+	nearbyPeople := filterPeople(q.Filter.apply(people), q.Distance, q.Unit, coordinates)
+	sortPeople(nearbyPeople, q.Sort)
+
+	return nearbyPeople, nil
 }
 
-func filterPeople(people dwp.People, distance int, cityCoordinates haversine.Coord) dwp.People {
-	var filteredPeople dwp.People
+// distanceFrom returns the distance between the coordinates and the person, measured in unit.
+func distanceFrom(coordinates haversine.Coord, person dwp.Person, unit Unit) float64 {
+	personCoordinates := haversine.Coord{
+		Lat: float64(person.Latitude),
+		Lon: float64(person.Longitude),
+	}
+
+	miles, kilometres := haversine.Distance(coordinates, personCoordinates)
+
+	switch unit {
+	case UnitKilometres:
+		return kilometres
+	case UnitMetres:
+		return UnitKilometres.Convert(kilometres, UnitMetres)
+	}
+
+	return miles
+}
+
+// locatePeople returns the people registered in a city with their distance from its coordinates, measured in unit.
+func locatePeople(people dwp.People, unit Unit, cityCoordinates haversine.Coord) People {
+	located := make(People, 0, len(people))
 
 	for _, person := range people {
-		personCoordinates := haversine.Coord{
-			Lat: float64(person.Latitude),
-			Lon: float64(person.Longitude),
-		}
+		d := distanceFrom(cityCoordinates, person, unit)
+		located = append(located, Person{Person: person, Match: MatchCity, Distance: &d})
+	}
+
+	return located
+}
+
+// filterPeople returns the people within distance of the coordinates, along with their distance. Both are measured in
+// unit.
+func filterPeople(people dwp.People, distance int, unit Unit, coordinates haversine.Coord) People {
+	filteredPeople := People{}
 
-		miles, _ := haversine.Distance(cityCoordinates, personCoordinates)
+	for _, person := range people {
+		d := distanceFrom(coordinates, person, unit)
 
-		if miles <= float64(distance) {
-			filteredPeople = append(filteredPeople, person)
+		if d <= float64(distance) {
+			filteredPeople = append(filteredPeople, Person{Person: person, Match: MatchProximity, Distance: &d})
 		}
 	}
 
 	return filteredPeople
 }
+
+// mergePeople combines the people registered in a city with the people near it, removing duplicates by ID. People in
+// both are marked as matching both. The result is ordered by ID.
+func mergePeople(cityPeople People, nearbyPeople People) People {
+	indexes := make(map[int]int, len(cityPeople)+len(nearbyPeople))
+	merged := make(People, 0, len(cityPeople)+len(nearbyPeople))
+
+	for _, person := range cityPeople {
+		if _, ok := indexes[person.ID]; !ok {
+			indexes[person.ID] = len(merged)
+			merged = append(merged, person)
+		}
+	}
+
+	for _, person := range nearbyPeople {
+		i, ok := indexes[person.ID]
+
+		switch {
+		case !ok:
+			indexes[person.ID] = len(merged)
+			merged = append(merged, person)
+		case merged[i].Match == MatchCity:
+			merged[i].Match = MatchBoth
+		}
+	}
+
+	sortPeople(merged, SortID)
+
+	return merged
+}
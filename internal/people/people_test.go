@@ -13,6 +13,7 @@ import (
 
 var mockRetrievePeople func() (dwp.People, error)
 var mockRetrievePeopleByCity func() (dwp.People, error)
+var mockRetrievePerson func(id int) (dwp.Person, error)
 
 type MockDwpClient struct{}
 
@@ -24,6 +25,10 @@ func (m MockDwpClient) RetrievePeopleByCity(ctx context.Context, city string) (d
 	return mockRetrievePeopleByCity()
 }
 
+func (m MockDwpClient) RetrievePerson(ctx context.Context, id int) (dwp.Person, error) {
+	return mockRetrievePerson(id)
+}
+
 func TestService_RetrievePeople(t *testing.T) {
 	t.Run("Given RetrievePeople is invoked when DwpClient returns people then people are returned", func(t *testing.T) {
 		expectedPeople := dwp.People{
@@ -59,7 +64,7 @@ func TestService_RetrievePeople(t *testing.T) {
 			Logger:    logging.New(logging.Info),
 		}
 
-		actualPeople, err := s.RetrievePeople(context.Background())
+		actualPeople, err := s.RetrievePeople(context.Background(), Filter{})
 
 		if err != nil {
 			t.Errorf("RetrievePeople() error = %v", err)
@@ -70,6 +75,24 @@ func TestService_RetrievePeople(t *testing.T) {
 		}
 	})
 
+	t.Run("Given RetrievePeople is invoked when DwpClient returns people out of order then people are ordered by ID", func(t *testing.T) {
+		mockRetrievePeople = func() (dwp.People, error) {
+			return dwp.People{{ID: 3}, {ID: 1}, {ID: 2}}, nil
+		}
+
+		s := Service{
+			DwpClient: MockDwpClient{},
+			Cities:    nil,
+			Logger:    logging.New(logging.Info),
+		}
+
+		actualPeople, _ := s.RetrievePeople(context.Background(), Filter{})
+
+		if expectedPeople := (dwp.People{{ID: 1}, {ID: 2}, {ID: 3}}); !reflect.DeepEqual(expectedPeople, actualPeople) {
+			t.Errorf("RetrievePeople() = %v, want %v", actualPeople, expectedPeople)
+		}
+	})
+
 	t.Run("Given RetrievePeople is invoked when DwpClient returns err then err is returned", func(t *testing.T) {
 		expectedErr := errors.New("test error")
 
@@ -85,7 +108,7 @@ func TestService_RetrievePeople(t *testing.T) {
 			Logger:    logging.New(logging.Info),
 		}
 
-		people, err := s.RetrievePeople(context.Background())
+		people, err := s.RetrievePeople(context.Background(), Filter{})
 
 		if !errors.Is(err, expectedErr) {
 			t.Errorf("RetrievePeople() error = %v, want %v", err, expectedErr)
@@ -97,6 +120,54 @@ func TestService_RetrievePeople(t *testing.T) {
 	})
 }
 
+func TestService_RetrievePerson(t *testing.T) {
+	t.Run("Given RetrievePerson is invoked when DwpClient returns person then person is returned", func(t *testing.T) {
+		expectedPerson := dwp.Person{ID: 1, FirstName: "Maurise", LastName: "Shieldon"}
+
+		mockRetrievePerson = func(id int) (dwp.Person, error) {
+			if id != 1 {
+				t.Errorf("RetrievePerson() id = %v, want 1", id)
+			}
+
+			return expectedPerson, nil
+		}
+
+		s := Service{
+			DwpClient: MockDwpClient{},
+			Cities:    nil,
+			Logger:    logging.New(logging.Info),
+		}
+
+		actualPerson, err := s.RetrievePerson(context.Background(), 1)
+
+		if err != nil {
+			t.Errorf("RetrievePerson() error = %v", err)
+		}
+
+		if actualPerson != expectedPerson {
+			t.Errorf("RetrievePerson() = %v, want %v", actualPerson, expectedPerson)
+		}
+	})
+
+	t.Run("Given RetrievePerson is invoked when DwpClient returns err then err is returned", func(t *testing.T) {
+		expectedErr := errors.New("test error")
+
+		mockRetrievePerson = func(id int) (dwp.Person, error) {
+			return dwp.Person{}, expectedErr
+		}
+
+		s := Service{
+			DwpClient: MockDwpClient{},
+			Cities:    nil,
+			Logger:    logging.New(logging.Info),
+		}
+
+		if _, err := s.RetrievePerson(context.Background(), 1); !errors.Is(err, expectedErr) {
+			t.Errorf("RetrievePerson() error = %v, want %v", err, expectedErr)
+		}
+	})
+}
+
 func TestService_RetrievePeopleByCity(t *testing.T) {
 	t.Run("Given city has been configured when RetrievePeople and RetrievePeopleByCity are successful then returns filtered people", func(t *testing.T) {
 		p := dwp.People{
@@ -154,30 +225,26 @@ func TestService_RetrievePeopleByCity(t *testing.T) {
 			Logger:    logging.New(logging.Info),
 		}
 
-		actualPeople, err := s.RetrievePeopleByCity(context.Background(), "london", 50)
+		actualPeople, err := s.RetrievePeopleByCity(context.Background(), "london", Query{Distance: 50})
 
 		if err != nil {
 			t.Errorf("RetrievePeople() error = %v", err)
 		}
 
-		var hasID1, hasID135, hasID396 bool
-
-		for _, person := range p {
-			if person.ID == 1 {
-				hasID1 = true
-			}
-
-			if person.ID == 135 {
-				hasID135 = true
-			}
+		expectedPeople := People{
+			{Person: p[1], Match: MatchProximity},
+			{Person: p[2], Match: MatchCity},
+			{Person: p[3], Match: MatchCity},
+		}
 
-			if person.ID == 396 {
-				hasID396 = true
-			}
+		if len(actualPeople) != len(expectedPeople) {
+			t.Fatalf("RetrievePeople() = %v, want %v", actualPeople, expectedPeople)
 		}
 
-		if !hasID1 || !hasID135 || !hasID396 || len(actualPeople) != 3 {
-			t.Errorf("RetrievePeople() = %v, want %v", actualPeople, p[1:])
+		for i, person := range actualPeople {
+			if person.Person != expectedPeople[i].Person || person.Match != expectedPeople[i].Match || person.Distance == nil {
+				t.Errorf("RetrievePeople() = %v, want %v", actualPeople, expectedPeople)
+			}
 		}
 	})
 
@@ -188,7 +255,7 @@ func TestService_RetrievePeopleByCity(t *testing.T) {
 			Logger:    logging.New(logging.Info),
 		}
 
-		p, err := s.RetrievePeopleByCity(context.Background(), "timbuctoo", 50)
+		p, err := s.RetrievePeopleByCity(context.Background(), "timbuctoo", Query{Distance: 50})
 
 		if err.Error() != "timbuctoo's coordinates have not been configured" {
 			t.Errorf("RetrievePeople() error = %v, want = timbuctoo's coordinates have not been configured", err)
@@ -229,7 +296,7 @@ func TestService_RetrievePeopleByCity(t *testing.T) {
 			Logger:    logging.New(logging.Info),
 		}
 
-		p, err := s.RetrievePeopleByCity(context.Background(), "london", 50)
+		p, err := s.RetrievePeopleByCity(context.Background(), "london", Query{Distance: 50})
 
 		if !errors.Is(err, expectedError) {
 			t.Errorf("RetrievePeople() error = %v, want = %v", err, expectedError)
@@ -270,7 +337,7 @@ func TestService_RetrievePeopleByCity(t *testing.T) {
 			Logger:    logging.New(logging.Info),
 		}
 
-		p, err := s.RetrievePeopleByCity(context.Background(), "london", 50)
+		p, err := s.RetrievePeopleByCity(context.Background(), "london", Query{Distance: 50})
 
 		if !errors.Is(err, expectedError) {
 			t.Errorf("RetrievePeople() error = %v, want = %v", err, expectedError)
@@ -282,6 +349,86 @@ func TestService_RetrievePeopleByCity(t *testing.T) {
 	})
 }
 
+func TestService_RetrievePeopleNear(t *testing.T) {
+	t.Run("Given RetrievePeopleNear is invoked when DwpClient returns people then people near the coordinates are returned", func(t *testing.T) {
+		mockRetrievePeople = func() (dwp.People, error) {
+			p := dwp.People{
+				{ID: 3, Latitude: dwp.Coordinate(51.6553959), Longitude: dwp.Coordinate(0.0572553)},
+				{ID: 2, Latitude: dwp.Coordinate(-2.9623869), Longitude: dwp.Coordinate(104.7399789)},
+				{ID: 1, Latitude: dwp.Coordinate(51.514248), Longitude: dwp.Coordinate(-0.093145)},
+			}
+			return p, nil
+		}
+
+		s := Service{
+			DwpClient: MockDwpClient{},
+			Cities:    nil,
+			Logger:    logging.New(logging.Info),
+		}
+
+		p, err := s.RetrievePeopleNear(context.Background(), haversine.Coord{Lat: 51.514248, Lon: -0.093145}, Query{Distance: 50})
+		if err != nil {
+			t.Errorf("RetrievePeopleNear() error = %v", err)
+		}
+
+		if len(p) != 2 || p[0].ID != 1 || p[1].ID != 3 || p[0].Match != MatchProximity {
+			t.Errorf("RetrievePeopleNear() = %v, want people 1 and 3 matched by proximity", p)
+		}
+	})
+
+	t.Run("Given RetrievePeopleNear is invoked with a filter then only matching people near the coordinates are returned", func(t *testing.T) {
+		mockRetrievePeople = func() (dwp.People, error) {
+			p := dwp.People{
+				{ID: 3, FirstName: "Ancell", Latitude: dwp.Coordinate(51.6553959), Longitude: dwp.Coordinate(0.0572553)},
+				{ID: 2, FirstName: "Bendix", Latitude: dwp.Coordinate(-2.9623869), Longitude: dwp.Coordinate(104.7399789)},
+				{ID: 1, FirstName: "Maurise", Latitude: dwp.Coordinate(51.514248), Longitude: dwp.Coordinate(-0.093145)},
+			}
+			return p, nil
+		}
+
+		s := Service{
+			DwpClient: MockDwpClient{},
+			Cities:    nil,
+			Logger:    logging.New(logging.Info),
+		}
+
+		q := Query{Distance: 50, Filter: Filter{FirstNameContains: "an"}}
+
+		p, err := s.RetrievePeopleNear(context.Background(), haversine.Coord{Lat: 51.514248, Lon: -0.093145}, q)
+		if err != nil {
+			t.Errorf("RetrievePeopleNear() error = %v", err)
+		}
+
+		if len(p) != 1 || p[0].ID != 3 {
+			t.Errorf("RetrievePeopleNear() = %v, want person 3 only", p)
+		}
+	})
+
+	t.Run("Given RetrievePeopleNear is invoked when DwpClient returns error then error is returned", func(t *testing.T) {
+		expectedError := errors.New("test error")
+
+		mockRetrievePeople = func() (dwp.People, error) {
+			return nil, expectedError
+		}
+
+		s := Service{
+			DwpClient: MockDwpClient{},
+			Cities:    nil,
+			Logger:    logging.New(logging.Info),
+		}
+
+		p, err := s.RetrievePeopleNear(context.Background(), haversine.Coord{}, Query{Distance: 50})
+
+		if !errors.Is(err, expectedError) {
+			t.Errorf("RetrievePeopleNear() error = %v, want = %v", err, expectedError)
+		}
+
+		if p != nil {
+			t.Errorf("RetrievePeopleNear() = %v, want nil", p)
+		}
+	})
+}
+
 func Test_filterPeople(t *testing.T) {
 	p := dwp.People{
 		{
@@ -304,9 +451,55 @@ func Test_filterPeople(t *testing.T) {
 		},
 	}
 
-	expectedPeople := p[:1]
+	actualPeople := filterPeople(p, 50, UnitMiles, haversine.Coord{Lat: 51.514248, Lon: -0.093145})
+
+	if len(actualPeople) != 1 || actualPeople[0].Person != p[0] || actualPeople[0].Match != MatchProximity {
+		t.Errorf("filterPeople() = %v, want %v", actualPeople, p[:1])
+	}
+
+	if d := *actualPeople[0].Distance; d < 11.6 || d > 11.7 {
+		t.Errorf("filterPeople() distance = %v, want 11.6 to 11.7", d)
+	}
+
+	actualPeople = filterPeople(p, 15, UnitKilometres, haversine.Coord{Lat: 51.514248, Lon: -0.093145})
+
+	if len(actualPeople) != 0 {
+		t.Errorf("filterPeople() = %v, want none within 15 km", actualPeople)
+	}
+
+	actualPeople = filterPeople(p, 20000, UnitMetres, haversine.Coord{Lat: 51.514248, Lon: -0.093145})
+
+	if len(actualPeople) != 1 {
+		t.Fatalf("filterPeople() = %v, want %v", actualPeople, p[:1])
+	}
+
+	if d := *actualPeople[0].Distance; d < 18800 || d > 18900 {
+		t.Errorf("filterPeople() distance = %v, want 18800 to 18900 metres", d)
+	}
+}
+
+func Test_locatePeople(t *testing.T) {
+	p := dwp.People{{ID: 1, Latitude: dwp.Coordinate(51.514248), Longitude: dwp.Coordinate(-0.093145)}}
+
+	actualPeople := locatePeople(p, UnitMiles, haversine.Coord{Lat: 51.514248, Lon: -0.093145})
+
+	if len(actualPeople) != 1 || actualPeople[0].Match != MatchCity || *actualPeople[0].Distance != 0 {
+		t.Errorf("locatePeople() = %v, want %v with match city and distance 0", actualPeople, p)
+	}
+}
+
+func Test_mergePeople(t *testing.T) {
+	cityPeople := People{{Person: dwp.Person{ID: 396}, Match: MatchCity}, {Person: dwp.Person{ID: 135}, Match: MatchCity}, {Person: dwp.Person{ID: 1}, Match: MatchCity}}
+	nearbyPeople := People{{Person: dwp.Person{ID: 2}, Match: MatchProximity}, {Person: dwp.Person{ID: 1}, Match: MatchProximity}, {Person: dwp.Person{ID: 2}, Match: MatchProximity}}
+
+	expectedPeople := People{
+		{Person: dwp.Person{ID: 1}, Match: MatchBoth},
+		{Person: dwp.Person{ID: 2}, Match: MatchProximity},
+		{Person: dwp.Person{ID: 135}, Match: MatchCity},
+		{Person: dwp.Person{ID: 396}, Match: MatchCity},
+	}
 
-	if actualPeople := filterPeople(p, 50, haversine.Coord{Lat: 51.514248, Lon: -0.093145}); !reflect.DeepEqual(actualPeople, expectedPeople) {
-		t.Errorf("filterPeople() = %v, want %v", actualPeople, expectedPeople)
+	if actualPeople := mergePeople(cityPeople, nearbyPeople); !reflect.DeepEqual(actualPeople, expectedPeople) {
+		t.Errorf("mergePeople() = %v, want %v", actualPeople, expectedPeople)
 	}
 }
@@ -0,0 +1,42 @@
+package people
+
+import "github.com/J-R-Oliver/dwp-assessment-go/pkg/dwp"
+
+// Match describes why a person was included in the people returned for a city.
+type Match int
+
+const (
+	MatchNone Match = iota
+	MatchCity
+	MatchProximity
+	MatchBoth
+)
+
+func (m Match) String() string {
+	switch m {
+	case MatchNone:
+		return ""
+	case MatchCity:
+		return "city"
+	case MatchProximity:
+		return "proximity"
+	case MatchBoth:
+		return "both"
+	}
+
+	return ""
+}
+
+func (m Match) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// Person is a dwp.Person annotated with why they were matched and their distance from the queried location, in the
+// queried unit.
+type Person struct {
+	dwp.Person
+	Match    Match    `json:"match,omitempty"`
+	Distance *float64 `json:"distance,omitempty"`
+}
+
+type People []Person
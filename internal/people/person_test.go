@@ -0,0 +1,64 @@
+package people
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/J-R-Oliver/dwp-assessment-go/pkg/dwp"
+)
+
+func TestMatch_String(t *testing.T) {
+	tests := []struct {
+		m    Match
+		want string
+	}{
+		{MatchNone, ""},
+		{MatchCity, "city"},
+		{MatchProximity, "proximity"},
+		{MatchBoth, "both"},
+		{Match(4), ""},
+	}
+
+	for _, tt := range tests {
+		if got := tt.m.String(); got != tt.want {
+			t.Errorf("String() = %v, want %v", got, tt.want)
+		}
+	}
+}
+
+func TestPerson_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		p    Person
+		want string
+	}{
+		{
+			"When person has a match then match is included",
+			Person{Person: dwp.Person{ID: 1}, Match: MatchBoth},
+			`{"ID":1,"first_name":"","last_name":"","Email":"","ip_address":"","Latitude":0,"Longitude":0,"match":"both"}`,
+		},
+		{
+			"When person has a distance then distance is included",
+			Person{Person: dwp.Person{ID: 1}, Match: MatchCity, Distance: func() *float64 { d := 0.0; return &d }()},
+			`{"ID":1,"first_name":"","last_name":"","Email":"","ip_address":"","Latitude":0,"Longitude":0,"match":"city","distance":0}`,
+		},
+		{
+			"When person has no match or distance then they are omitted",
+			Person{Person: dwp.Person{ID: 1}},
+			`{"ID":1,"first_name":"","last_name":"","Email":"","ip_address":"","Latitude":0,"Longitude":0}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(tt.p)
+			if err != nil {
+				t.Errorf("json.Marshal() error = %v", err)
+			}
+
+			if string(b) != tt.want {
+				t.Errorf("json.Marshal() = %s, want %s", b, tt.want)
+			}
+		})
+	}
+}
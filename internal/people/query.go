@@ -0,0 +1,70 @@
+package people
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Sort is the order in which people are returned.
+type Sort int
+
+const (
+	SortID Sort = iota
+	SortDistanceAscending
+	SortDistanceDescending
+)
+
+func (s Sort) String() string {
+	switch s {
+	case SortID:
+		return "id"
+	case SortDistanceAscending:
+		return "distance"
+	case SortDistanceDescending:
+		return "-distance"
+	}
+
+	return ""
+}
+
+// ParseSort parses a sort query. An empty string or id orders by ID, distance orders by ascending distance and
+// -distance by descending distance.
+func ParseSort(s string) (Sort, error) {
+	switch s {
+	case "", "id":
+		return SortID, nil
+	case "distance":
+		return SortDistanceAscending, nil
+	case "-distance":
+		return SortDistanceDescending, nil
+	}
+
+	return 0, fmt.Errorf("%s is not a valid sort - valid options are id, distance or -distance", s)
+}
+
+// Query describes which people to retrieve around a location and how to order them.
+type Query struct {
+	// Distance is the maximum distance from the location, measured in Unit.
+	Distance int
+	// Unit is the unit of Distance and of the distances returned with each person.
+	Unit   Unit
+	Sort   Sort
+	Filter Filter
+}
+
+// sortPeople orders people in place. Ties, and people without a distance, are ordered by ID.
+func sortPeople(people People, s Sort) {
+	sort.SliceStable(people, func(i, j int) bool {
+		a, b := people[i], people[j]
+
+		if s != SortID && a.Distance != nil && b.Distance != nil && *a.Distance != *b.Distance {
+			if s == SortDistanceDescending {
+				return *a.Distance > *b.Distance
+			}
+
+			return *a.Distance < *b.Distance
+		}
+
+		return a.ID < b.ID
+	})
+}
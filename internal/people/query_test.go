@@ -0,0 +1,93 @@
+package people
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/J-R-Oliver/dwp-assessment-go/pkg/dwp"
+)
+
+func TestParseSort(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    Sort
+		wantErr bool
+	}{
+		{"When passed empty string then returns SortID", "", SortID, false},
+		{"When passed id then returns SortID", "id", SortID, false},
+		{"When passed distance then returns SortDistanceAscending", "distance", SortDistanceAscending, false},
+		{"When passed -distance then returns SortDistanceDescending", "-distance", SortDistanceDescending, false},
+		{"When passed invalid sort then returns error", "name", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSort(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseSort() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("ParseSort() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSort_String(t *testing.T) {
+	tests := []struct {
+		s    Sort
+		want string
+	}{
+		{SortID, "id"},
+		{SortDistanceAscending, "distance"},
+		{SortDistanceDescending, "-distance"},
+		{Sort(3), ""},
+	}
+
+	for _, tt := range tests {
+		if got := tt.s.String(); got != tt.want {
+			t.Errorf("String() = %v, want %v", got, tt.want)
+		}
+	}
+}
+
+func Test_sortPeople(t *testing.T) {
+	near, far := 1.0, 2.0
+
+	people := func() People {
+		return People{
+			{Person: dwp.Person{ID: 3}, Distance: &far},
+			{Person: dwp.Person{ID: 2}, Distance: &near},
+			{Person: dwp.Person{ID: 1}, Distance: &far},
+		}
+	}
+
+	tests := []struct {
+		name string
+		s    Sort
+		want []int
+	}{
+		{"When sort is id then people are ordered by ID", SortID, []int{1, 2, 3}},
+		{"When sort is distance then people are ordered by ascending distance then ID", SortDistanceAscending, []int{2, 1, 3}},
+		{"When sort is -distance then people are ordered by descending distance then ID", SortDistanceDescending, []int{1, 3, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := people()
+			sortPeople(p, tt.s)
+
+			var got []int
+			for _, person := range p {
+				got = append(got, person.ID)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("sortPeople() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
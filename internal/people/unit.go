@@ -0,0 +1,55 @@
+package people
+
+import "fmt"
+
+// Unit is the unit in which distances are queried and returned.
+type Unit int
+
+const (
+	UnitMiles Unit = iota
+	UnitKilometres
+	UnitMetres
+)
+
+func (u Unit) String() string {
+	switch u {
+	case UnitMiles:
+		return "mi"
+	case UnitKilometres:
+		return "km"
+	case UnitMetres:
+		return "m"
+	}
+
+	return ""
+}
+
+// ParseUnit parses a unit query. Valid options are mi, km or m.
+func ParseUnit(s string) (Unit, error) {
+	switch s {
+	case "mi":
+		return UnitMiles, nil
+	case "km":
+		return UnitKilometres, nil
+	case "m":
+		return UnitMetres, nil
+	}
+
+	return 0, fmt.Errorf("%s is not a valid unit - valid options are mi, km or m", s)
+}
+
+// Convert returns distance, measured in u, measured in the unit to.
+func (u Unit) Convert(distance float64, to Unit) float64 {
+	return distance * u.metres() / to.metres()
+}
+
+func (u Unit) metres() float64 {
+	switch u {
+	case UnitKilometres:
+		return 1000
+	case UnitMetres:
+		return 1
+	}
+
+	return 1609.344
+}
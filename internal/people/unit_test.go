@@ -0,0 +1,57 @@
+package people
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseUnit(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    Unit
+		wantErr bool
+	}{
+		{"mi", UnitMiles, false},
+		{"km", UnitKilometres, false},
+		{"m", UnitMetres, false},
+		{"", 0, true},
+		{"miles", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseUnit(tt.s)
+
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseUnit(%q) error = %v, wantErr %v", tt.s, err, tt.wantErr)
+		}
+
+		if got != tt.want {
+			t.Errorf("ParseUnit(%q) = %v, want %v", tt.s, got, tt.want)
+		}
+
+		if !tt.wantErr && got.String() != tt.s {
+			t.Errorf("String() = %v, want %v", got.String(), tt.s)
+		}
+	}
+}
+
+func TestUnit_Convert(t *testing.T) {
+	tests := []struct {
+		distance float64
+		from     Unit
+		to       Unit
+		want     float64
+	}{
+		{50, UnitMiles, UnitMiles, 50},
+		{50, UnitMiles, UnitKilometres, 80.4672},
+		{50, UnitMiles, UnitMetres, 80467.2},
+		{2.5, UnitKilometres, UnitMetres, 2500},
+		{1609.344, UnitMetres, UnitMiles, 1},
+	}
+
+	for _, tt := range tests {
+		if got := tt.from.Convert(tt.distance, tt.to); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("Convert(%v, %v) = %v, want %v", tt.distance, tt.to, got, tt.want)
+		}
+	}
+}
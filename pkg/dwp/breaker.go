@@ -0,0 +1,236 @@
+package dwp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is matched by errors returned from a CircuitBreaker that is rejecting requests.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitOpenError is returned by a CircuitBreaker instead of calling the upstream API. RetryAfter is the time remaining
+// until the breaker will next allow a trial request.
+type CircuitOpenError struct {
+	RetryAfter time.Duration
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("%s - retry after %s", ErrCircuitOpen, e.RetryAfter)
+}
+
+// Is allows CircuitOpenError to be matched with errors.Is(err, ErrCircuitOpen).
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+type CircuitState int
+
+const (
+	CircuitClosed CircuitState = iota
+	CircuitOpen
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+
+	return ""
+}
+
+func (s CircuitState) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// BreakerSettings configures when a CircuitBreaker opens and how it recovers.
+type BreakerSettings struct {
+	// FailureThreshold is the number of consecutive failures that open the circuit. Values below one are treated as one.
+	FailureThreshold int
+	// CoolDown is how long the circuit stays open before trial requests are allowed.
+	CoolDown time.Duration
+	// HalfOpenRequests is the number of trial requests allowed while half-open. The circuit closes once they have all
+	// succeeded. Values below one are treated as one.
+	HalfOpenRequests int
+}
+
+// CircuitBreaker is a Client that wraps another Client and stops calling it once it has failed repeatedly. While open,
+// requests fail fast with a *CircuitOpenError.
+type CircuitBreaker struct {
+	client   Client
+	settings BreakerSettings
+	now      func() time.Time
+
+	mu                sync.Mutex
+	state             CircuitState
+	failures          int
+	openedAt          time.Time
+	halfOpenRequests  int
+	halfOpenSuccesses int
+}
+
+// NewCircuitBreaker returns a closed CircuitBreaker wrapping client.
+func NewCircuitBreaker(client Client, settings BreakerSettings) *CircuitBreaker {
+	if settings.FailureThreshold < 1 {
+		settings.FailureThreshold = 1
+	}
+
+	if settings.HalfOpenRequests < 1 {
+		settings.HalfOpenRequests = 1
+	}
+
+	return &CircuitBreaker{
+		client:   client,
+		settings: settings,
+		now:      time.Now,
+	}
+}
+
+// State returns the current state of the circuit.
+func (b *CircuitBreaker) State() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == CircuitOpen && b.now().Sub(b.openedAt) >= b.settings.CoolDown {
+		return CircuitHalfOpen
+	}
+
+	return b.state
+}
+
+// RetrievePeople calls the wrapped Client's RetrievePeople if the circuit allows it.
+func (b *CircuitBreaker) RetrievePeople(ctx context.Context) (People, error) {
+	var people People
+
+	err := b.execute(ctx, func() error {
+		var err error
+		people, err = b.client.RetrievePeople(ctx)
+
+		return err
+	})
+
+	return people, err
+}
+
+// RetrievePeopleByCity calls the wrapped Client's RetrievePeopleByCity if the circuit allows it.
+func (b *CircuitBreaker) RetrievePeopleByCity(ctx context.Context, city string) (People, error) {
+	var people People
+
+	err := b.execute(ctx, func() error {
+		var err error
+		people, err = b.client.RetrievePeopleByCity(ctx, city)
+
+		return err
+	})
+
+	return people, err
+}
+
+// RetrievePerson calls the wrapped Client's RetrievePerson if the circuit allows it.
+func (b *CircuitBreaker) RetrievePerson(ctx context.Context, id int) (Person, error) {
+	var person Person
+
+	err := b.execute(ctx, func() error {
+		var err error
+		person, err = b.client.RetrievePerson(ctx, id)
+
+		return err
+	})
+
+	return person, err
+}
+
+func (b *CircuitBreaker) execute(ctx context.Context, call func() error) error {
+	if err := b.allow(); err != nil {
+		return err
+	}
+
+	err := call()
+
+	b.record(ctx, err)
+
+	return err
+}
+
+// allow reports whether a request may be made, moving an open circuit to half-open once the cool-down has elapsed.
+func (b *CircuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == CircuitOpen {
+		remaining := b.settings.CoolDown - b.now().Sub(b.openedAt)
+		if remaining > 0 {
+			return &CircuitOpenError{RetryAfter: remaining}
+		}
+
+		b.state = CircuitHalfOpen
+		b.halfOpenRequests = 0
+		b.halfOpenSuccesses = 0
+	}
+
+	if b.state == CircuitHalfOpen {
+		if b.halfOpenRequests >= b.settings.HalfOpenRequests {
+			return &CircuitOpenError{RetryAfter: b.settings.CoolDown}
+		}
+
+		b.halfOpenRequests++
+	}
+
+	return nil
+}
+
+// record updates the circuit with the outcome of a request. Requests cancelled by the caller, or rejected by the
+// upstream API because of the request itself, say nothing about the health of the upstream API and are not counted as
+// failures. Requests that outlast their deadline are counted, as that is how a hung upstream API fails.
+func (b *CircuitBreaker) record(ctx context.Context, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var apiError *APIError
+	if errors.As(err, &apiError) && !apiError.upstreamFailure() {
+		err = nil
+	}
+
+	abandoned := err != nil && errors.Is(ctx.Err(), context.Canceled)
+
+	switch b.state {
+	case CircuitClosed:
+		switch {
+		case err == nil:
+			b.failures = 0
+		case !abandoned:
+			b.failures++
+			if b.failures >= b.settings.FailureThreshold {
+				b.open()
+			}
+		}
+	case CircuitHalfOpen:
+		switch {
+		case err == nil:
+			b.halfOpenSuccesses++
+			if b.halfOpenSuccesses >= b.settings.HalfOpenRequests {
+				b.state = CircuitClosed
+				b.failures = 0
+			}
+		case abandoned:
+			b.halfOpenRequests--
+		default:
+			b.open()
+		}
+	case CircuitOpen:
+	}
+}
+
+func (b *CircuitBreaker) open() {
+	b.state = CircuitOpen
+	b.openedAt = b.now()
+	b.failures = 0
+}
@@ -0,0 +1,240 @@
+package dwp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type mockClient struct {
+	retrievePeople       func(ctx context.Context) (People, error)
+	retrievePeopleByCity func(ctx context.Context, city string) (People, error)
+	retrievePerson       func(ctx context.Context, id int) (Person, error)
+}
+
+func (m mockClient) RetrievePeople(ctx context.Context) (People, error) {
+	return m.retrievePeople(ctx)
+}
+
+func (m mockClient) RetrievePeopleByCity(ctx context.Context, city string) (People, error) {
+	return m.retrievePeopleByCity(ctx, city)
+}
+
+func (m mockClient) RetrievePerson(ctx context.Context, id int) (Person, error) {
+	return m.retrievePerson(ctx, id)
+}
+
+func failingClient(calls *int) mockClient {
+	return mockClient{
+		retrievePeople: func(ctx context.Context) (People, error) {
+			*calls++
+			return nil, errors.New("test error")
+		},
+		retrievePeopleByCity: func(ctx context.Context, city string) (People, error) {
+			*calls++
+			return nil, errors.New("test error")
+		},
+	}
+}
+
+func TestCircuitState_String(t *testing.T) {
+	tests := []struct {
+		s    CircuitState
+		want string
+	}{
+		{CircuitClosed, "closed"},
+		{CircuitOpen, "open"},
+		{CircuitHalfOpen, "half-open"},
+		{CircuitState(3), ""},
+	}
+
+	for _, tt := range tests {
+		if got := tt.s.String(); got != tt.want {
+			t.Errorf("String() = %v, want %v", got, tt.want)
+		}
+	}
+}
+
+func TestCircuitOpenError_Is(t *testing.T) {
+	var err error = &CircuitOpenError{RetryAfter: time.Second}
+
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("errors.Is(%v, ErrCircuitOpen) = false, want true", err)
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	t.Run("When failures reach the threshold then circuit opens and fails fast", func(t *testing.T) {
+		calls := 0
+		b := NewCircuitBreaker(failingClient(&calls), BreakerSettings{FailureThreshold: 2, CoolDown: time.Minute})
+
+		b.RetrievePeople(context.Background())                 //nolint:errcheck
+		b.RetrievePeopleByCity(context.Background(), "London") //nolint:errcheck
+
+		if b.State() != CircuitOpen {
+			t.Errorf("State() = %v, want open", b.State())
+		}
+
+		_, err := b.RetrievePeople(context.Background())
+
+		var e *CircuitOpenError
+		if !errors.As(err, &e) || e.RetryAfter <= 0 {
+			t.Errorf("RetrievePeople() error = %v, want *CircuitOpenError", err)
+		}
+
+		if calls != 2 {
+			t.Errorf("RetrievePeople() upstream calls = %d, want 2", calls)
+		}
+	})
+
+	t.Run("When a request succeeds then consecutive failures are reset", func(t *testing.T) {
+		fail := true
+		c := mockClient{
+			retrievePeople: func(ctx context.Context) (People, error) {
+				if fail {
+					return nil, errors.New("test error")
+				}
+				return People{}, nil
+			},
+		}
+
+		b := NewCircuitBreaker(c, BreakerSettings{FailureThreshold: 2, CoolDown: time.Minute})
+
+		b.RetrievePeople(context.Background()) //nolint:errcheck
+		fail = false
+		b.RetrievePeople(context.Background()) //nolint:errcheck
+		fail = true
+		b.RetrievePeople(context.Background()) //nolint:errcheck
+
+		if b.State() != CircuitClosed {
+			t.Errorf("State() = %v, want closed", b.State())
+		}
+	})
+
+	t.Run("When upstream API rejects the request itself then failure is not counted", func(t *testing.T) {
+		c := mockClient{
+			retrievePeople: func(ctx context.Context) (People, error) {
+				return nil, &APIError{StatusCode: http.StatusNotFound}
+			},
+		}
+
+		b := NewCircuitBreaker(c, BreakerSettings{FailureThreshold: 1, CoolDown: time.Minute})
+
+		b.RetrievePeople(context.Background()) //nolint:errcheck
+
+		if b.State() != CircuitClosed {
+			t.Errorf("State() = %v, want closed", b.State())
+		}
+	})
+
+	t.Run("When request is abandoned by the caller then failure is not counted", func(t *testing.T) {
+		calls := 0
+		b := NewCircuitBreaker(failingClient(&calls), BreakerSettings{FailureThreshold: 1, CoolDown: time.Minute})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		b.RetrievePeople(ctx) //nolint:errcheck
+
+		if b.State() != CircuitClosed {
+			t.Errorf("State() = %v, want closed", b.State())
+		}
+	})
+
+	t.Run("When upstream API hangs until the deadline then failures are counted", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}))
+		defer server.Close()
+
+		b := NewCircuitBreaker(NewClient(server.URL, *server.Client()), BreakerSettings{FailureThreshold: 2, CoolDown: time.Minute})
+
+		for i := 0; i < 2; i++ {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+
+			if _, err := b.RetrievePeople(ctx); !errors.Is(err, ErrTimeout) {
+				t.Errorf("RetrievePeople() error = %v, want %v", err, ErrTimeout)
+			}
+
+			cancel()
+		}
+
+		if b.State() != CircuitOpen {
+			t.Errorf("State() = %v, want open", b.State())
+		}
+	})
+
+	t.Run("When cool-down has elapsed then trial request is allowed and success closes circuit", func(t *testing.T) {
+		fail := true
+		c := mockClient{
+			retrievePeople: func(ctx context.Context) (People, error) {
+				if fail {
+					return nil, errors.New("test error")
+				}
+				return People{}, nil
+			},
+		}
+
+		now := time.Now()
+		b := NewCircuitBreaker(c, BreakerSettings{FailureThreshold: 1, CoolDown: time.Minute})
+		b.now = func() time.Time { return now }
+
+		b.RetrievePeople(context.Background()) //nolint:errcheck
+
+		now = now.Add(time.Minute)
+
+		if b.State() != CircuitHalfOpen {
+			t.Errorf("State() = %v, want half-open", b.State())
+		}
+
+		fail = false
+
+		if _, err := b.RetrievePeople(context.Background()); err != nil {
+			t.Errorf("RetrievePeople() error = %v", err)
+		}
+
+		if b.State() != CircuitClosed {
+			t.Errorf("State() = %v, want closed", b.State())
+		}
+	})
+
+	t.Run("When trial request fails then circuit re-opens", func(t *testing.T) {
+		calls := 0
+		now := time.Now()
+		b := NewCircuitBreaker(failingClient(&calls), BreakerSettings{FailureThreshold: 1, CoolDown: time.Minute})
+		b.now = func() time.Time { return now }
+
+		b.RetrievePeople(context.Background()) //nolint:errcheck
+
+		now = now.Add(time.Minute)
+
+		b.RetrievePeople(context.Background()) //nolint:errcheck
+
+		if b.State() != CircuitOpen {
+			t.Errorf("State() = %v, want open", b.State())
+		}
+
+		if calls != 2 {
+			t.Errorf("RetrievePeople() upstream calls = %d, want 2", calls)
+		}
+	})
+
+	t.Run("When half-open trial requests are in flight then further requests fail fast", func(t *testing.T) {
+		now := time.Now()
+		b := NewCircuitBreaker(nil, BreakerSettings{FailureThreshold: 1, CoolDown: time.Minute, HalfOpenRequests: 1})
+		b.now = func() time.Time { return now }
+		b.state = CircuitOpen
+		b.openedAt = now.Add(-time.Minute)
+
+		if err := b.allow(); err != nil {
+			t.Errorf("allow() error = %v", err)
+		}
+
+		if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+			t.Errorf("allow() error = %v, want %v", err, ErrCircuitOpen)
+		}
+	})
+}
@@ -0,0 +1,236 @@
+package dwp
+
+import (
+	"container/list"
+	"context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// refreshTimeout bounds background refreshes, which are not tied to any caller's context.
+const refreshTimeout = time.Minute
+
+// personOverhead approximates the memory used by a Person excluding the contents of its strings.
+const personOverhead = 96
+
+// CacheSettings configures how long responses are cached and how much is cached.
+type CacheSettings struct {
+	// PeopleTTL is how long RetrievePeople responses are fresh.
+	PeopleTTL time.Duration
+	// PeopleByCityTTL is how long RetrievePeopleByCity responses are fresh.
+	PeopleByCityTTL time.Duration
+	// PersonTTL is how long RetrievePerson responses are fresh.
+	PersonTTL time.Duration
+	// StaleWhileRevalidate is how long after expiring a response is still served while it is refreshed in the
+	// background.
+	StaleWhileRevalidate time.Duration
+	// StaleIfError is how long after expiring a response is still served if the upstream API fails.
+	StaleIfError time.Duration
+	// MaxEntries is the maximum number of cached responses. Zero means unlimited.
+	MaxEntries int
+	// MaxBytes is the approximate maximum size of all cached responses. Zero means unlimited.
+	MaxBytes int64
+}
+
+type cacheEntry struct {
+	key      string
+	people   People
+	size     int64
+	storedAt time.Time
+}
+
+// Cache is a Client that wraps another Client and caches its responses in memory. The least recently used responses
+// are evicted once the configured budget is exceeded.
+type Cache struct {
+	client   Client
+	settings CacheSettings
+	now      func() time.Time
+
+	mu         sync.Mutex
+	entries    map[string]*list.Element
+	lru        *list.List
+	bytes      int64
+	refreshing map[string]bool
+	wg         sync.WaitGroup
+}
+
+// NewCache returns an empty Cache wrapping client.
+func NewCache(client Client, settings CacheSettings) *Cache {
+	return &Cache{
+		client:     client,
+		settings:   settings,
+		now:        time.Now,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+		refreshing: make(map[string]bool),
+	}
+}
+
+// RetrievePeople returns the cached response from the wrapped Client's RetrievePeople.
+func (c *Cache) RetrievePeople(ctx context.Context) (People, error) {
+	return c.get(ctx, "people", c.settings.PeopleTTL, c.client.RetrievePeople)
+}
+
+// RetrievePeopleByCity returns the cached response from the wrapped Client's RetrievePeopleByCity.
+func (c *Cache) RetrievePeopleByCity(ctx context.Context, city string) (People, error) {
+	return c.get(ctx, "city/"+city, c.settings.PeopleByCityTTL, func(ctx context.Context) (People, error) {
+		return c.client.RetrievePeopleByCity(ctx, city)
+	})
+}
+
+// RetrievePerson returns the cached response from the wrapped Client's RetrievePerson. A person that does not exist is
+// not cached.
+func (c *Cache) RetrievePerson(ctx context.Context, id int) (Person, error) {
+	people, err := c.get(ctx, "user/"+strconv.Itoa(id), c.settings.PersonTTL, func(ctx context.Context) (People, error) {
+		return retrievePersonAsPeople(ctx, c.client, id)
+	})
+	if err != nil {
+		return Person{}, err
+	}
+
+	return people[0], nil
+}
+
+// retrievePersonAsPeople calls the client's RetrievePerson, returning the person as People so that the response can be
+// shared and cached in the same way as other responses.
+func retrievePersonAsPeople(ctx context.Context, client Client, id int) (People, error) {
+	person, err := client.RetrievePerson(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return People{person}, nil
+}
+
+func (c *Cache) get(ctx context.Context, key string, ttl time.Duration, fetch func(context.Context) (People, error)) (People, error) {
+	entry, age, ok := c.lookup(key)
+
+	if ok && age < ttl {
+		return copyPeople(entry.people), nil
+	}
+
+	if ok && age < ttl+c.settings.StaleWhileRevalidate {
+		c.refresh(ctx, key, fetch)
+		return copyPeople(entry.people), nil
+	}
+
+	people, err := fetch(ctx)
+	if err != nil {
+		if ok && age < ttl+c.settings.StaleIfError {
+			return copyPeople(entry.people), nil
+		}
+
+		return nil, err
+	}
+
+	c.store(key, people)
+
+	return copyPeople(people), nil
+}
+
+// refresh fetches a fresh response in the background, unless a refresh of the key is already in progress. The refresh
+// carries the values of ctx, such as its request ID, but is not cancelled with it.
+func (c *Cache) refresh(ctx context.Context, key string, fetch func(context.Context) (People, error)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.refreshing[key] {
+		return
+	}
+
+	c.refreshing[key] = true
+	c.wg.Add(1)
+
+	go func() {
+		defer c.wg.Done()
+
+		ctx, cancel := context.WithTimeout(detachedContext{ctx}, refreshTimeout)
+		defer cancel()
+
+		people, err := fetch(ctx)
+		if err == nil {
+			c.store(key, people)
+		}
+
+		c.mu.Lock()
+		delete(c.refreshing, key)
+		c.mu.Unlock()
+	}()
+}
+
+func (c *Cache) lookup(key string) (*cacheEntry, time.Duration, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, 0, false
+	}
+
+	c.lru.MoveToFront(element)
+
+	entry := element.Value.(*cacheEntry)
+
+	return entry, c.now().Sub(entry.storedAt), true
+}
+
+func (c *Cache) store(key string, people People) {
+	entry := &cacheEntry{
+		key:      key,
+		people:   copyPeople(people),
+		size:     sizeOfPeople(people),
+		storedAt: c.now(),
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+
+	if c.settings.MaxBytes > 0 && entry.size > c.settings.MaxBytes {
+		return
+	}
+
+	c.entries[key] = c.lru.PushFront(entry)
+	c.bytes += entry.size
+
+	for c.overBudget() {
+		c.remove(c.lru.Back())
+	}
+}
+
+func (c *Cache) overBudget() bool {
+	return c.settings.MaxEntries > 0 && c.lru.Len() > c.settings.MaxEntries ||
+		c.settings.MaxBytes > 0 && c.bytes > c.settings.MaxBytes
+}
+
+func (c *Cache) remove(element *list.Element) {
+	entry := c.lru.Remove(element).(*cacheEntry)
+	delete(c.entries, entry.key)
+	c.bytes -= entry.size
+}
+
+// copyPeople returns a copy of people so that callers cannot modify cached responses.
+func copyPeople(people People) People {
+	if people == nil {
+		return nil
+	}
+
+	c := make(People, len(people))
+	copy(c, people)
+
+	return c
+}
+
+func sizeOfPeople(people People) int64 {
+	var size int64
+
+	for _, p := range people {
+		size += personOverhead + int64(len(p.FirstName)+len(p.LastName)+len(p.Email)+len(p.IPAddress))
+	}
+
+	return size
+}
@@ -0,0 +1,252 @@
+package dwp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func countingClient(calls *int, people People, err *error) mockClient {
+	return mockClient{
+		retrievePeople: func(ctx context.Context) (People, error) {
+			*calls++
+			if *err != nil {
+				return nil, *err
+			}
+			return people, nil
+		},
+		retrievePeopleByCity: func(ctx context.Context, city string) (People, error) {
+			*calls++
+			if *err != nil {
+				return nil, *err
+			}
+			return people, nil
+		},
+		retrievePerson: func(ctx context.Context, id int) (Person, error) {
+			*calls++
+			if *err != nil {
+				return Person{}, *err
+			}
+			return people[0], nil
+		},
+	}
+}
+
+func TestCache(t *testing.T) {
+	people := People{{ID: 1, FirstName: "Maurise", LastName: "Shieldon"}}
+	settings := CacheSettings{
+		PeopleTTL:            time.Minute,
+		PeopleByCityTTL:      time.Minute,
+		PersonTTL:            time.Minute,
+		StaleWhileRevalidate: time.Minute,
+		StaleIfError:         time.Hour,
+	}
+
+	t.Run("When response is fresh then it is served from the cache", func(t *testing.T) {
+		calls := 0
+		var err error
+
+		c := NewCache(countingClient(&calls, people, &err), settings)
+
+		c.RetrievePeople(context.Background()) //nolint:errcheck
+
+		got, _ := c.RetrievePeople(context.Background())
+
+		if calls != 1 || !reflect.DeepEqual(got, people) {
+			t.Errorf("RetrievePeople() = %v, calls = %d, want %v and 1 call", got, calls, people)
+		}
+	})
+
+	t.Run("When responses are for different cities then they are cached separately", func(t *testing.T) {
+		calls := 0
+		var err error
+
+		c := NewCache(countingClient(&calls, people, &err), settings)
+
+		c.RetrievePeopleByCity(context.Background(), "London")     //nolint:errcheck
+		c.RetrievePeopleByCity(context.Background(), "Manchester") //nolint:errcheck
+		c.RetrievePeopleByCity(context.Background(), "London")     //nolint:errcheck
+
+		if calls != 2 {
+			t.Errorf("RetrievePeopleByCity() calls = %d, want 2", calls)
+		}
+	})
+
+	t.Run("When people are for different IDs then they are cached separately", func(t *testing.T) {
+		calls := 0
+		var err error
+
+		c := NewCache(countingClient(&calls, people, &err), settings)
+
+		c.RetrievePerson(context.Background(), 1) //nolint:errcheck
+		c.RetrievePerson(context.Background(), 2) //nolint:errcheck
+
+		got, _ := c.RetrievePerson(context.Background(), 1)
+
+		if calls != 2 || got != people[0] {
+			t.Errorf("RetrievePerson() = %v, calls = %d, want %v and 2 calls", got, calls, people[0])
+		}
+	})
+
+	t.Run("When person is not found then the error is not cached", func(t *testing.T) {
+		calls := 0
+		err := error(&APIError{StatusCode: http.StatusNotFound})
+
+		c := NewCache(countingClient(&calls, people, &err), settings)
+
+		c.RetrievePerson(context.Background(), 1) //nolint:errcheck
+
+		if _, got := c.RetrievePerson(context.Background(), 1); !errors.Is(got, ErrNotFound) || calls != 2 {
+			t.Errorf("RetrievePerson() error = %v, calls = %d, want %v and 2 calls", got, calls, ErrNotFound)
+		}
+	})
+
+	t.Run("When cached response is modified by the caller then the cache is unaffected", func(t *testing.T) {
+		calls := 0
+		var err error
+
+		c := NewCache(countingClient(&calls, People{{ID: 1}}, &err), settings)
+
+		got, _ := c.RetrievePeople(context.Background())
+		got[0].ID = 2
+
+		if got, _ = c.RetrievePeople(context.Background()); got[0].ID != 1 {
+			t.Errorf("RetrievePeople() ID = %d, want 1", got[0].ID)
+		}
+	})
+
+	t.Run("When response is stale within stale-while-revalidate then it is served and refreshed", func(t *testing.T) {
+		calls := 0
+		var err error
+		now := time.Now()
+
+		c := NewCache(countingClient(&calls, people, &err), settings)
+		c.now = func() time.Time { return now }
+
+		c.RetrievePeople(context.Background()) //nolint:errcheck
+
+		now = now.Add(90 * time.Second)
+
+		got, e := c.RetrievePeople(context.Background())
+		if e != nil || !reflect.DeepEqual(got, people) {
+			t.Errorf("RetrievePeople() = %v, %v, want %v", got, e, people)
+		}
+
+		c.wg.Wait()
+
+		if calls != 2 {
+			t.Errorf("RetrievePeople() calls = %d, want 2", calls)
+		}
+
+		if _, age, _ := c.lookup("people"); age != 0 {
+			t.Errorf("RetrievePeople() age after refresh = %v, want 0", age)
+		}
+	})
+
+	t.Run("When response has expired then upstream is called", func(t *testing.T) {
+		calls := 0
+		var err error
+		now := time.Now()
+
+		c := NewCache(countingClient(&calls, people, &err), settings)
+		c.now = func() time.Time { return now }
+
+		c.RetrievePeople(context.Background()) //nolint:errcheck
+
+		now = now.Add(3 * time.Minute)
+
+		c.RetrievePeople(context.Background()) //nolint:errcheck
+
+		if calls != 2 {
+			t.Errorf("RetrievePeople() calls = %d, want 2", calls)
+		}
+	})
+
+	t.Run("When upstream fails within stale-if-error then stale response is served", func(t *testing.T) {
+		calls := 0
+		var err error
+		now := time.Now()
+
+		c := NewCache(countingClient(&calls, people, &err), settings)
+		c.now = func() time.Time { return now }
+
+		c.RetrievePeople(context.Background()) //nolint:errcheck
+
+		now = now.Add(30 * time.Minute)
+		err = errors.New("test error")
+
+		got, e := c.RetrievePeople(context.Background())
+		if e != nil || !reflect.DeepEqual(got, people) {
+			t.Errorf("RetrievePeople() = %v, %v, want %v", got, e, people)
+		}
+	})
+
+	t.Run("When upstream fails after stale-if-error then error is returned", func(t *testing.T) {
+		calls := 0
+		var err error
+		now := time.Now()
+
+		c := NewCache(countingClient(&calls, people, &err), settings)
+		c.now = func() time.Time { return now }
+
+		c.RetrievePeople(context.Background()) //nolint:errcheck
+
+		now = now.Add(2 * time.Hour)
+		err = errors.New("test error")
+
+		if _, e := c.RetrievePeople(context.Background()); !errors.Is(e, err) {
+			t.Errorf("RetrievePeople() error = %v, want %v", e, err)
+		}
+	})
+
+	t.Run("When max entries is exceeded then least recently used response is evicted", func(t *testing.T) {
+		calls := 0
+		var err error
+
+		s := settings
+		s.MaxEntries = 2
+
+		c := NewCache(countingClient(&calls, people, &err), s)
+
+		c.RetrievePeopleByCity(context.Background(), "London")     //nolint:errcheck
+		c.RetrievePeopleByCity(context.Background(), "Manchester") //nolint:errcheck
+		c.RetrievePeopleByCity(context.Background(), "London")     //nolint:errcheck
+		c.RetrievePeopleByCity(context.Background(), "Leeds")      //nolint:errcheck
+
+		if _, _, ok := c.lookup("city/Manchester"); ok {
+			t.Errorf("lookup() Manchester cached, want evicted")
+		}
+
+		if _, _, ok := c.lookup("city/London"); !ok {
+			t.Errorf("lookup() London evicted, want cached")
+		}
+	})
+
+	t.Run("When response exceeds max bytes then it is not cached", func(t *testing.T) {
+		calls := 0
+		var err error
+
+		s := settings
+		s.MaxBytes = 10
+
+		c := NewCache(countingClient(&calls, people, &err), s)
+
+		c.RetrievePeople(context.Background()) //nolint:errcheck
+		c.RetrievePeople(context.Background()) //nolint:errcheck
+
+		if calls != 2 || c.bytes != 0 {
+			t.Errorf("RetrievePeople() calls = %d, bytes = %d, want 2 calls and 0 bytes", calls, c.bytes)
+		}
+	})
+}
+
+func Test_sizeOfPeople(t *testing.T) {
+	p := People{{FirstName: "ab", LastName: "cd", Email: "ef", IPAddress: "gh"}}
+
+	if got := sizeOfPeople(p); got != personOverhead+8 {
+		t.Errorf("sizeOfPeople() = %d, want %d", got, personOverhead+8)
+	}
+}
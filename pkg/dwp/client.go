@@ -4,56 +4,175 @@ package dwp // import "github.com/J-R-Oliver/dwp-assessment-go/pkg/dwp"
 import (
 	"context"
 	"encoding/json"
-	"fmt"
+	"errors"
 	"io"
-	"log"
+	"net"
 	"net/http"
+	"time"
+
+	"github.com/J-R-Oliver/dwp-assessment-go/pkg/requestid"
 )
 
 type Client interface {
 	RetrievePeople(ctx context.Context) (People, error)
 	RetrievePeopleByCity(ctx context.Context, city string) (People, error)
+	RetrievePerson(ctx context.Context, id int) (Person, error)
 }
 
+// Option configures optional behaviour of the Client returned by NewClient.
+type Option func(*client)
+
 type client struct {
-	baseURL    string
-	httpClient http.Client
+	baseURL     string
+	httpClient  http.Client
+	retryPolicy RetryPolicy
 }
 
 // NewClient returns an instance Client configured to user the provided http.Client and base URL
-func NewClient(baseURL string, httpClient http.Client) Client {
-	return &client{
+func NewClient(baseURL string, httpClient http.Client, options ...Option) Client {
+	c := &client{
 		baseURL:    baseURL,
 		httpClient: httpClient,
 	}
+
+	for _, option := range options {
+		option(c)
+	}
+
+	return c
 }
 
 // makeRequest is a helper function to make HTTP requests and store the result in the value pointed to by v. v should
-// provide all the necessary fields and configuration for json.Unmarshal.
+// provide all the necessary fields and configuration for json.Unmarshal. Failed requests are retried according to the
+// client's RetryPolicy, for as long as the request context allows. A Retry-After longer than the policy's MaxDelay is
+// not waited for, and the *APIError is returned instead. The request ID carried by the request context, if
+// any, is forwarded in the X-Request-ID header.
 func (c client) makeRequest(r *http.Request, v interface{}) error {
 	r.Header.Set("Accept-Encoding", "application/json")
 
+	if id, ok := requestid.FromContext(r.Context()); ok {
+		r.Header.Set(requestid.Header, id)
+	}
+
+	ctx := r.Context()
+	attempts := c.retryPolicy.attempts()
+
+	for attempt := 1; ; attempt++ {
+		err := c.doRequest(r.Clone(ctx), v)
+		if err == nil {
+			return nil
+		}
+
+		if attempt >= attempts || ctx.Err() != nil {
+			return err
+		}
+
+		delay := c.retryPolicy.backoff(attempt)
+
+		var apiError *APIError
+
+		switch {
+		case errors.As(err, &apiError):
+			if !c.retryPolicy.retryableStatusCode(apiError.StatusCode) {
+				return err
+			}
+
+			if apiError.RetryAfter > 0 {
+				if !c.retryPolicy.retryAfter(apiError.RetryAfter) {
+					return err
+				}
+
+				delay = apiError.RetryAfter
+			}
+		case errors.Is(err, ErrDecode) || !c.retryPolicy.retryableError(err):
+			return err
+		}
+
+		if !wait(ctx, delay) {
+			return err
+		}
+	}
+}
+
+// doRequest makes a single attempt at the request. A response with a status code other than 200 results in an
+// *APIError.
+func (c client) doRequest(r *http.Request, v interface{}) error {
 	response, err := c.httpClient.Do(r)
 	if err != nil {
-		return err
+		return classifyTransportError(err)
 	}
 
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("request failed with status code %d and body %s", response.StatusCode, response.Body)
+		body, _ := io.ReadAll(io.LimitReader(response.Body, maxErrorBodyLength))
+
+		return &APIError{
+			StatusCode: response.StatusCode,
+			Body:       string(body),
+			Endpoint:   r.URL.Path,
+			RequestID:  response.Header.Get("X-Request-ID"),
+			RetryAfter: parseRetryAfter(response.Header.Get("Retry-After"), time.Now()),
+		}
 	}
 
-	bytes, err := io.ReadAll(response.Body)
+	body := &bodyReader{Reader: response.Body}
+
+	err = decode(body, v)
 	if err != nil {
-		return err
+		// a truncated body can appear malformed before the read fails, so the remainder is read to find out
+		io.Copy(io.Discard, body) //nolint:errcheck
+	}
+
+	if body.err != nil {
+		return classifyTransportError(body.err)
 	}
 
-	err = json.Unmarshal(bytes, v)
 	if err != nil {
-		log.Println(err)
-		return err
+		return &sentinelError{ErrDecode, err}
 	}
 
 	return nil
 }
+
+// streamDecoder is implemented by values that decode a response body incrementally, rather than holding the whole body
+// in memory.
+type streamDecoder interface {
+	decodeStream(d *json.Decoder) error
+}
+
+// decode decodes the JSON value read from r into v.
+func decode(r io.Reader, v interface{}) error {
+	d := json.NewDecoder(r)
+
+	if s, ok := v.(streamDecoder); ok {
+		return s.decodeStream(d)
+	}
+
+	return d.Decode(v)
+}
+
+// bodyReader records the first error reading a response body, so that it can be told apart from a decoding error.
+type bodyReader struct {
+	io.Reader
+	err error
+}
+
+func (b *bodyReader) Read(p []byte) (int, error) {
+	n, err := b.Reader.Read(p)
+	if err != nil && err != io.EOF && b.err == nil {
+		b.err = err
+	}
+
+	return n, err
+}
+
+// classifyTransportError marks timeouts so that they can be matched with errors.Is(err, ErrTimeout).
+func classifyTransportError(err error) error {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() || errors.Is(err, context.DeadlineExceeded) {
+		return &sentinelError{ErrTimeout, err}
+	}
+
+	return err
+}
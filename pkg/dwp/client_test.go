@@ -1,14 +1,18 @@
 package dwp
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"net"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/J-R-Oliver/dwp-assessment-go/pkg/requestid"
 )
 
 func TestNewClient(t *testing.T) {
@@ -25,6 +29,16 @@ func TestNewClient(t *testing.T) {
 	}
 }
 
+func TestNewClient_WithRetryPolicy(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 3, BaseDelay: time.Second}
+
+	got := NewClient("http://test-domain:1020", http.Client{}, WithRetryPolicy(p)).(*client)
+
+	if !reflect.DeepEqual(got.retryPolicy, p) {
+		t.Errorf("NewClient() retryPolicy = %v, want %v", got.retryPolicy, p)
+	}
+}
+
 func Test_client_makeRequest(t *testing.T) {
 	t.Run("When called with http.Request with Get HTTP method then server is called with Get HTTP method", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -47,6 +61,29 @@ func Test_client_makeRequest(t *testing.T) {
 		c.makeRequest(r, nil) //nolint:errcheck
 	})
 
+	t.Run("When request context carries a request ID then server is called with X-Request-ID header", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if id := r.Header.Get("X-Request-ID"); id != "test-request-id" {
+				t.Errorf("makeRequest() request X-Request-ID = %s, want: test-request-id", id)
+			}
+		}))
+		defer server.Close()
+
+		c := &client{
+			baseURL:    server.URL,
+			httpClient: *server.Client(),
+		}
+
+		ctx := requestid.NewContext(context.Background(), "test-request-id")
+
+		r, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/test-path", nil)
+		if err != nil {
+			t.Errorf("makeRequest() error building test http.Request = %v", err)
+		}
+
+		c.makeRequest(r, nil) //nolint:errcheck
+	})
+
 	t.Run("When called with http.Request with path /test-path then server is called with path /test-path", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			if r.URL.Path != "/test-path" {
@@ -138,9 +175,11 @@ func Test_client_makeRequest(t *testing.T) {
 		}
 	})
 
-	t.Run("When server responds with HTTP error code then error is returned", func(t *testing.T) {
+	t.Run("When server responds with HTTP error code then APIError is returned", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Request-ID", "test-request-id")
 			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(strings.Repeat("a", maxErrorBodyLength+1))) //nolint:errcheck
 		}))
 		defer server.Close()
 
@@ -154,8 +193,16 @@ func Test_client_makeRequest(t *testing.T) {
 			t.Errorf("makeRequest() error building test http.Request = %v", err)
 		}
 
-		if err := c.makeRequest(r, nil); err.Error() != "request failed with status code 500 and body {}" {
-			t.Errorf("makeRequest() error = %v, want = request failed with status code 500 and body {}", err)
+		want := &APIError{
+			StatusCode: http.StatusInternalServerError,
+			Body:       strings.Repeat("a", maxErrorBodyLength),
+			Endpoint:   "/test-path",
+			RequestID:  "test-request-id",
+		}
+
+		var e *APIError
+		if err := c.makeRequest(r, nil); !errors.As(err, &e) || !reflect.DeepEqual(e, want) {
+			t.Errorf("makeRequest() error = %v, want = %v", err, want)
 		}
 	})
 
@@ -202,8 +249,158 @@ func Test_client_makeRequest(t *testing.T) {
 			key string
 		}{}
 		var e *json.SyntaxError
-		if err := c.makeRequest(r, &v); !errors.As(err, &e) {
+
+		err = c.makeRequest(r, &v)
+		if !errors.As(err, &e) {
 			t.Errorf("makeRequest() error = %v, want = invalid character 'N' looking for beginning of value", err)
 		}
+
+		if !errors.Is(err, ErrDecode) {
+			t.Errorf("makeRequest() error = %v, want = %v", err, ErrDecode)
+		}
+	})
+}
+
+func Test_client_makeRequest_retry(t *testing.T) {
+	policy := RetryPolicy{
+		MaxAttempts:          3,
+		BaseDelay:            time.Millisecond,
+		RetryableStatusCodes: []int{http.StatusServiceUnavailable},
+		RetryableErrors:      []ErrorClass{ErrorClassUnexpectedEOF},
+	}
+
+	t.Run("When server responds with retryable status code then request is retried until successful", func(t *testing.T) {
+		calls := 0
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+
+			if calls < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+
+			w.Write([]byte(`{"test":"response"}`)) //nolint:errcheck
+		}))
+		defer server.Close()
+
+		c := NewClient(server.URL, *server.Client(), WithRetryPolicy(policy)).(*client)
+
+		r, _ := http.NewRequest(http.MethodGet, server.URL+"/test-path", nil)
+
+		v := struct{ Test string }{}
+
+		if err := c.makeRequest(r, &v); err != nil {
+			t.Errorf("makeRequest() error = %v", err)
+		}
+
+		if calls != 3 || v.Test != "response" {
+			t.Errorf("makeRequest() calls = %d, body = %v, want 3 calls and body response", calls, v)
+		}
+	})
+
+	t.Run("When server keeps responding with retryable status code then error is returned after max attempts", func(t *testing.T) {
+		calls := 0
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		c := NewClient(server.URL, *server.Client(), WithRetryPolicy(policy)).(*client)
+
+		r, _ := http.NewRequest(http.MethodGet, server.URL+"/test-path", nil)
+
+		if err := c.makeRequest(r, nil); err == nil || calls != 3 {
+			t.Errorf("makeRequest() error = %v, calls = %d, want error after 3 calls", err, calls)
+		}
 	})
+
+	t.Run("When server responds with non-retryable status code then request is not retried", func(t *testing.T) {
+		calls := 0
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		c := NewClient(server.URL, *server.Client(), WithRetryPolicy(policy)).(*client)
+
+		r, _ := http.NewRequest(http.MethodGet, server.URL+"/test-path", nil)
+
+		if err := c.makeRequest(r, nil); err == nil || calls != 1 {
+			t.Errorf("makeRequest() error = %v, calls = %d, want error after 1 call", err, calls)
+		}
+	})
+
+	t.Run("When response body is truncated then request is retried", func(t *testing.T) {
+		calls := 0
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+
+			if calls == 1 {
+				w.Header().Add("Content-Length", "100")
+				w.Write([]byte(`[{"ID":1},`)) //nolint:errcheck
+
+				return
+			}
+
+			w.Write([]byte(`[{"ID":1},{"ID":2}]`)) //nolint:errcheck
+		}))
+		defer server.Close()
+
+		c := NewClient(server.URL, *server.Client(), WithRetryPolicy(policy)).(*client)
+
+		r, _ := http.NewRequest(http.MethodGet, server.URL+"/test-path", nil)
+
+		var people People
+
+		if err := c.makeRequest(r, &people); err != nil || calls != 2 {
+			t.Errorf("makeRequest() error = %v, calls = %d, want success after 2 calls", err, calls)
+		}
+
+		if want := (People{{ID: 1}, {ID: 2}}); !reflect.DeepEqual(people, want) {
+			t.Errorf("makeRequest() = %v, want %v", people, want)
+		}
+	})
+
+	t.Run("When Retry-After outlasts the context deadline then request is not retried", func(t *testing.T) {
+		calls := 0
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		c := NewClient(server.URL, *server.Client(), WithRetryPolicy(policy)).(*client)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		r, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/test-path", nil)
+
+		if err := c.makeRequest(r, nil); err == nil || calls != 1 {
+			t.Errorf("makeRequest() error = %v, calls = %d, want error after 1 call", err, calls)
+		}
+	})
+}
+
+func Test_client_makeRequest_timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, http.Client{Timeout: time.Millisecond}).(*client)
+
+	r, _ := http.NewRequest(http.MethodGet, server.URL+"/test-path", nil)
+
+	if err := c.makeRequest(r, nil); !errors.Is(err, ErrTimeout) {
+		t.Errorf("makeRequest() error = %v, want = %v", err, ErrTimeout)
+	}
 }
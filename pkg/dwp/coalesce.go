@@ -0,0 +1,267 @@
+package dwp
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// detachedContext carries the values of its parent but is never cancelled and has no deadline.
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+
+func (c detachedContext) Value(key any) any {
+	return c.parent.Value(key)
+}
+
+// callContext is the context of a shared call. It carries the values of the leader's context, and its deadline is
+// the latest deadline of the callers waiting on the call, which changes as callers join and give up.
+type callContext struct {
+	parent context.Context
+	done   chan struct{}
+
+	mu       sync.Mutex
+	deadline time.Time
+	timer    *time.Timer
+	err      error
+}
+
+func newCallContext(parent context.Context) *callContext {
+	return &callContext{parent: parent, done: make(chan struct{})}
+}
+
+func (c *callContext) Deadline() (time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.deadline, !c.deadline.IsZero()
+}
+
+func (c *callContext) Done() <-chan struct{} { return c.done }
+
+func (c *callContext) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.err
+}
+
+func (c *callContext) Value(key any) any {
+	return c.parent.Value(key)
+}
+
+// setDeadline changes the deadline, after which the context is cancelled with context.DeadlineExceeded. A zero
+// deadline removes the deadline.
+func (c *callContext) setDeadline(deadline time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.err != nil || deadline.Equal(c.deadline) {
+		return
+	}
+
+	if c.timer != nil {
+		c.timer.Stop()
+		c.timer = nil
+	}
+
+	c.deadline = deadline
+
+	if !deadline.IsZero() {
+		c.timer = time.AfterFunc(time.Until(deadline), func() { c.cancel(context.DeadlineExceeded) })
+	}
+}
+
+// cancel cancels the context with err, unless it has already been cancelled.
+func (c *callContext) cancel(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.err != nil {
+		return
+	}
+
+	if c.timer != nil {
+		c.timer.Stop()
+	}
+
+	c.err = err
+	close(c.done)
+}
+
+type inFlightCall struct {
+	done    chan struct{}
+	ctx     *callContext
+	waiters int
+	// deadlines are the deadlines of the waiters that have one, and unbounded is the number of waiters that do not.
+	deadlines []time.Time
+	unbounded int
+	people    People
+	err       error
+}
+
+// join adds a waiter with the context to the call. It must be called with c.mu held.
+func (call *inFlightCall) join(ctx context.Context) {
+	call.waiters++
+
+	if deadline, ok := ctx.Deadline(); ok {
+		call.deadlines = append(call.deadlines, deadline)
+	} else {
+		call.unbounded++
+	}
+
+	call.ctx.setDeadline(call.latestDeadline())
+}
+
+// remove removes a waiter with the context from the call. It must be called with c.mu held.
+func (call *inFlightCall) remove(ctx context.Context) {
+	call.waiters--
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		call.unbounded--
+		return
+	}
+
+	for i, d := range call.deadlines {
+		if d.Equal(deadline) {
+			call.deadlines = append(call.deadlines[:i], call.deadlines[i+1:]...)
+			break
+		}
+	}
+}
+
+// latestDeadline returns the latest deadline of the waiters, or the zero time if any waiter has no deadline.
+func (call *inFlightCall) latestDeadline() time.Time {
+	var latest time.Time
+
+	if call.unbounded > 0 {
+		return latest
+	}
+
+	for _, d := range call.deadlines {
+		if d.After(latest) {
+			latest = d
+		}
+	}
+
+	return latest
+}
+
+// Coalescer is a Client that wraps another Client so that concurrent identical requests share a single upstream call
+// and its result. The shared call is not tied to the context of the caller that started it; it is cancelled once every
+// caller waiting on it has given up, and its deadline is the latest deadline of the callers waiting on it.
+type Coalescer struct {
+	client Client
+
+	mu    sync.Mutex
+	calls map[string]*inFlightCall
+}
+
+// NewCoalescer returns a Coalescer wrapping client.
+func NewCoalescer(client Client) *Coalescer {
+	return &Coalescer{
+		client: client,
+		calls:  make(map[string]*inFlightCall),
+	}
+}
+
+// RetrievePeople joins, or starts, a shared call to the wrapped Client's RetrievePeople.
+func (c *Coalescer) RetrievePeople(ctx context.Context) (People, error) {
+	return c.do(ctx, "people", c.client.RetrievePeople)
+}
+
+// RetrievePeopleByCity joins, or starts, a shared call to the wrapped Client's RetrievePeopleByCity.
+func (c *Coalescer) RetrievePeopleByCity(ctx context.Context, city string) (People, error) {
+	return c.do(ctx, "city/"+city, func(ctx context.Context) (People, error) {
+		return c.client.RetrievePeopleByCity(ctx, city)
+	})
+}
+
+// RetrievePerson joins, or starts, a shared call to the wrapped Client's RetrievePerson.
+func (c *Coalescer) RetrievePerson(ctx context.Context, id int) (Person, error) {
+	people, err := c.do(ctx, "user/"+strconv.Itoa(id), func(ctx context.Context) (People, error) {
+		return retrievePersonAsPeople(ctx, c.client, id)
+	})
+	if err != nil {
+		return Person{}, err
+	}
+
+	return people[0], nil
+}
+
+func (c *Coalescer) do(ctx context.Context, key string, fetch func(context.Context) (People, error)) (People, error) {
+	c.mu.Lock()
+
+	call, ok := c.calls[key]
+	if !ok {
+		call = c.start(ctx, key, fetch)
+	}
+
+	call.join(ctx)
+
+	c.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return copyPeople(call.people), call.err
+	case <-ctx.Done():
+		c.leave(ctx, key, call)
+		return nil, ctx.Err()
+	}
+}
+
+// start begins a shared call. It must be called with c.mu held.
+func (c *Coalescer) start(ctx context.Context, key string, fetch func(context.Context) (People, error)) *inFlightCall {
+	call := &inFlightCall{
+		done: make(chan struct{}),
+		ctx:  newCallContext(ctx),
+	}
+
+	c.calls[key] = call
+
+	go func() {
+		defer call.ctx.cancel(context.Canceled)
+
+		call.people, call.err = fetch(call.ctx)
+
+		c.mu.Lock()
+		c.forget(key, call)
+		c.mu.Unlock()
+
+		close(call.done)
+	}()
+
+	return call
+}
+
+// leave removes a waiter from the call, cancelling it with the waiter's error if no waiters remain or otherwise moving
+// its deadline to the latest deadline of the remaining waiters.
+func (c *Coalescer) leave(ctx context.Context, key string, call *inFlightCall) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	call.remove(ctx)
+
+	if call.waiters == 0 {
+		call.ctx.cancel(ctx.Err())
+		c.forget(key, call)
+
+		return
+	}
+
+	call.ctx.setDeadline(call.latestDeadline())
+}
+
+// forget removes the call so that subsequent requests start a new one. It must be called with c.mu held.
+func (c *Coalescer) forget(key string, call *inFlightCall) {
+	if c.calls[key] == call {
+		delete(c.calls, key)
+	}
+}
@@ -0,0 +1,251 @@
+package dwp
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCoalescer(t *testing.T) {
+	people := People{{ID: 1, FirstName: "Maurise", LastName: "Shieldon"}}
+
+	t.Run("When concurrent identical requests are made then upstream is called once", func(t *testing.T) {
+		var calls int32
+
+		release := make(chan struct{})
+
+		c := NewCoalescer(mockClient{
+			retrievePeopleByCity: func(ctx context.Context, city string) (People, error) {
+				atomic.AddInt32(&calls, 1)
+				<-release
+				return people, nil
+			},
+		})
+
+		var wg sync.WaitGroup
+
+		results := make([]People, 5)
+
+		for i := range results {
+			wg.Add(1)
+
+			go func(i int) {
+				defer wg.Done()
+				results[i], _ = c.RetrievePeopleByCity(context.Background(), "London")
+			}(i)
+		}
+
+		waitForWaiters(t, c, "city/London", len(results))
+		close(release)
+		wg.Wait()
+
+		if atomic.LoadInt32(&calls) != 1 {
+			t.Errorf("RetrievePeopleByCity() upstream calls = %d, want 1", calls)
+		}
+
+		for _, got := range results {
+			if !reflect.DeepEqual(got, people) {
+				t.Errorf("RetrievePeopleByCity() = %v, want %v", got, people)
+			}
+		}
+	})
+
+	t.Run("When requests are for different endpoints then they are not shared", func(t *testing.T) {
+		var calls int32
+
+		c := NewCoalescer(mockClient{
+			retrievePeople: func(ctx context.Context) (People, error) {
+				atomic.AddInt32(&calls, 1)
+				return people, nil
+			},
+			retrievePeopleByCity: func(ctx context.Context, city string) (People, error) {
+				atomic.AddInt32(&calls, 1)
+				return people, nil
+			},
+			retrievePerson: func(ctx context.Context, id int) (Person, error) {
+				atomic.AddInt32(&calls, 1)
+				return people[0], nil
+			},
+		})
+
+		c.RetrievePeople(context.Background())                 //nolint:errcheck
+		c.RetrievePeopleByCity(context.Background(), "London") //nolint:errcheck
+
+		if person, err := c.RetrievePerson(context.Background(), 1); err != nil || person != people[0] {
+			t.Errorf("RetrievePerson() = %v, %v, want %v", person, err, people[0])
+		}
+
+		if atomic.LoadInt32(&calls) != 3 {
+			t.Errorf("upstream calls = %d, want 3", calls)
+		}
+	})
+
+	t.Run("When the leader's context is cancelled then followers still receive the result", func(t *testing.T) {
+		release := make(chan struct{})
+
+		c := NewCoalescer(mockClient{
+			retrievePeople: func(ctx context.Context) (People, error) {
+				select {
+				case <-release:
+					return people, nil
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				}
+			},
+		})
+
+		leaderCtx, cancelLeader := context.WithCancel(context.Background())
+
+		leaderErr := make(chan error)
+
+		go func() {
+			_, err := c.RetrievePeople(leaderCtx)
+			leaderErr <- err
+		}()
+
+		waitForWaiters(t, c, "people", 1)
+
+		followerResult := make(chan People)
+
+		go func() {
+			p, _ := c.RetrievePeople(context.Background())
+			followerResult <- p
+		}()
+
+		waitForWaiters(t, c, "people", 2)
+
+		cancelLeader()
+
+		if err := <-leaderErr; !errors.Is(err, context.Canceled) {
+			t.Errorf("RetrievePeople() leader error = %v, want %v", err, context.Canceled)
+		}
+
+		close(release)
+
+		if got := <-followerResult; !reflect.DeepEqual(got, people) {
+			t.Errorf("RetrievePeople() follower = %v, want %v", got, people)
+		}
+	})
+
+	t.Run("When every caller gives up then the upstream call is cancelled", func(t *testing.T) {
+		upstreamErr := make(chan error)
+
+		c := NewCoalescer(mockClient{
+			retrievePeople: func(ctx context.Context) (People, error) {
+				<-ctx.Done()
+				upstreamErr <- ctx.Err()
+				return nil, ctx.Err()
+			},
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		go func() {
+			waitForWaiters(t, c, "people", 1)
+			cancel()
+		}()
+
+		if _, err := c.RetrievePeople(ctx); !errors.Is(err, context.Canceled) {
+			t.Errorf("RetrievePeople() error = %v, want %v", err, context.Canceled)
+		}
+
+		if err := <-upstreamErr; !errors.Is(err, context.Canceled) {
+			t.Errorf("upstream context error = %v, want %v", err, context.Canceled)
+		}
+	})
+
+	t.Run("When the leader has a deadline then the upstream call has the latest deadline of the waiters", func(t *testing.T) {
+		upstreamDeadline := make(chan time.Time, 1)
+		upstreamErr := make(chan error, 1)
+
+		c := NewCoalescer(mockClient{
+			retrievePeople: func(ctx context.Context) (People, error) {
+				<-ctx.Done()
+				deadline, _ := ctx.Deadline()
+				upstreamDeadline <- deadline
+				upstreamErr <- ctx.Err()
+				return nil, ctx.Err()
+			},
+		})
+
+		leaderCtx, cancelLeader := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancelLeader()
+
+		followerCtx, cancelFollower := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancelFollower()
+
+		followerDeadline, _ := followerCtx.Deadline()
+		followerErr := make(chan error)
+
+		go func() {
+			waitForWaiters(t, c, "people", 1)
+
+			_, err := c.RetrievePeople(followerCtx)
+			followerErr <- err
+		}()
+
+		if _, err := c.RetrievePeople(leaderCtx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("RetrievePeople() leader error = %v, want %v", err, context.DeadlineExceeded)
+		}
+
+		select {
+		case err := <-upstreamErr:
+			t.Fatalf("upstream context error = %v after leader gave up, want follower still waiting", err)
+		default:
+		}
+
+		if err := <-followerErr; !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("RetrievePeople() follower error = %v, want %v", err, context.DeadlineExceeded)
+		}
+
+		if err := <-upstreamErr; !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("upstream context error = %v, want %v", err, context.DeadlineExceeded)
+		}
+
+		if got := <-upstreamDeadline; !got.Equal(followerDeadline) {
+			t.Errorf("upstream context deadline = %v, want %v", got, followerDeadline)
+		}
+	})
+
+	t.Run("When the shared call is made then it carries the values of the leader's context", func(t *testing.T) {
+		type key struct{}
+
+		c := NewCoalescer(mockClient{
+			retrievePeople: func(ctx context.Context) (People, error) {
+				if ctx.Value(key{}) != "value" {
+					t.Errorf("upstream context value = %v, want value", ctx.Value(key{}))
+				}
+				return people, nil
+			},
+		})
+
+		c.RetrievePeople(context.WithValue(context.Background(), key{}, "value")) //nolint:errcheck
+	})
+}
+
+func waitForWaiters(t *testing.T, c *Coalescer, key string, waiters int) {
+	t.Helper()
+
+	for i := 0; i < 1000; i++ {
+		c.mu.Lock()
+		call, ok := c.calls[key]
+		n := 0
+
+		if ok {
+			n = call.waiters
+		}
+		c.mu.Unlock()
+
+		if n == waiters {
+			return
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	t.Fatalf("timed out waiting for %d waiters on %s", waiters, key)
+}
@@ -0,0 +1,67 @@
+package dwp
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// maxErrorBodyLength is the maximum number of bytes of an error response body retained in an APIError.
+const maxErrorBodyLength = 512
+
+var (
+	// ErrTimeout is matched by errors caused by a request to the upstream API timing out.
+	ErrTimeout = errors.New("request to upstream API timed out")
+	// ErrDecode is matched by errors caused by an upstream API response that could not be decoded.
+	ErrDecode = errors.New("unable to decode upstream API response")
+	// ErrNotFound is matched by an APIError with a 404 status code.
+	ErrNotFound = errors.New("not found in upstream API")
+)
+
+// APIError is returned when the upstream API responds with a status code other than 200.
+type APIError struct {
+	StatusCode int
+	// Body is the response body, truncated to 512 bytes.
+	Body string
+	// Endpoint is the path of the request.
+	Endpoint string
+	// RequestID is the value of the response's X-Request-ID header, if present.
+	RequestID string
+	// RetryAfter is the delay requested by the response's Retry-After header, if present.
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("request to %s failed with status code %d and body %s", e.Endpoint, e.StatusCode, e.Body)
+}
+
+// Is allows an APIError with a 404 status code to be matched with errors.Is(err, ErrNotFound).
+func (e *APIError) Is(target error) bool {
+	return target == ErrNotFound && e.StatusCode == http.StatusNotFound
+}
+
+// upstreamFailure reports whether the error indicates that the upstream API is unhealthy, rather than that the request
+// itself was at fault.
+func (e *APIError) upstreamFailure() bool {
+	return e.StatusCode >= http.StatusInternalServerError || e.StatusCode == http.StatusTooManyRequests
+}
+
+// sentinelError associates an underlying error with one of the package's sentinel errors, so that it can be matched
+// by either.
+type sentinelError struct {
+	sentinel error
+	err      error
+}
+
+func (e *sentinelError) Error() string {
+	return fmt.Sprintf("%s: %s", e.sentinel, e.err)
+}
+
+func (e *sentinelError) Is(target error) bool {
+	return target == e.sentinel
+}
+
+func (e *sentinelError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,86 @@
+package dwp
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestAPIError_Error(t *testing.T) {
+	e := &APIError{StatusCode: http.StatusBadGateway, Body: "bad gateway", Endpoint: "/users"}
+
+	if got := e.Error(); got != "request to /users failed with status code 502 and body bad gateway" {
+		t.Errorf("Error() = %v, want request to /users failed with status code 502 and body bad gateway", got)
+	}
+}
+
+func TestAPIError_Is(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			"When status code is 404 then matches ErrNotFound",
+			fmt.Errorf("wrapped: %w", &APIError{StatusCode: http.StatusNotFound}),
+			true,
+		},
+		{
+			"When status code is not 404 then does not match ErrNotFound",
+			fmt.Errorf("wrapped: %w", &APIError{StatusCode: http.StatusInternalServerError}),
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errors.Is(tt.err, ErrNotFound); got != tt.want {
+				t.Errorf("errors.Is(%v, ErrNotFound) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAPIError_upstreamFailure(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		want       bool
+	}{
+		{http.StatusBadRequest, false},
+		{http.StatusNotFound, false},
+		{http.StatusTooManyRequests, true},
+		{http.StatusInternalServerError, true},
+		{http.StatusServiceUnavailable, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.statusCode), func(t *testing.T) {
+			if got := (&APIError{StatusCode: tt.statusCode}).upstreamFailure(); got != tt.want {
+				t.Errorf("upstreamFailure() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_sentinelError(t *testing.T) {
+	underlying := errors.New("test error")
+
+	var err error = &sentinelError{ErrDecode, underlying}
+
+	if !errors.Is(err, ErrDecode) {
+		t.Errorf("errors.Is(%v, ErrDecode) = false, want true", err)
+	}
+
+	if !errors.Is(err, underlying) {
+		t.Errorf("errors.Is(%v, underlying) = false, want true", err)
+	}
+
+	if errors.Is(err, ErrTimeout) {
+		t.Errorf("errors.Is(%v, ErrTimeout) = true, want false", err)
+	}
+
+	if err.Error() != "unable to decode upstream API response: test error" {
+		t.Errorf("Error() = %v, want unable to decode upstream API response: test error", err)
+	}
+}
@@ -2,8 +2,10 @@ package dwp
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
 )
 
@@ -39,6 +41,40 @@ type Person struct {
 
 type People []Person
 
+// decodeStream decodes a JSON array of people one person at a time, so that the whole response body is not held in
+// memory alongside the decoded people. Any people already in p are discarded first, so that a request retried after a
+// partially decoded body does not duplicate them.
+func (p *People) decodeStream(d *json.Decoder) error {
+	*p = (*p)[:0]
+
+	t, err := d.Token()
+	if err != nil {
+		return err
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	if t != json.Delim('[') {
+		return fmt.Errorf("decodeStream: expected an array of people, got %v", t)
+	}
+
+	for d.More() {
+		var person Person
+
+		if err = d.Decode(&person); err != nil {
+			return err
+		}
+
+		*p = append(*p, person)
+	}
+
+	_, err = d.Token()
+
+	return err
+}
+
 // RetrievePeople returns all people from the '/users' endpoint. If any error is returned then People will be nil.
 func (c client) RetrievePeople(ctx context.Context) (People, error) {
 	request, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/users", nil)
@@ -59,7 +95,7 @@ func (c client) RetrievePeople(ctx context.Context) (People, error) {
 // RetrievePeopleByCity returns all people from the '/city/{city}/users' endpoint. If any error is returned then People
 // will be nil.
 func (c client) RetrievePeopleByCity(ctx context.Context, city string) (People, error) {
-	path := fmt.Sprintf("/city/%s/users", city)
+	path := fmt.Sprintf("/city/%s/users", url.PathEscape(city))
 
 	request, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
 	if err != nil {
@@ -75,3 +111,23 @@ func (c client) RetrievePeopleByCity(ctx context.Context, city string) (People,
 
 	return people, nil
 }
+
+// RetrievePerson returns the person with the ID from the '/user/{id}' endpoint. If the person does not exist then the
+// error matches ErrNotFound.
+func (c client) RetrievePerson(ctx context.Context, id int) (Person, error) {
+	path := fmt.Sprintf("/user/%d", id)
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return Person{}, fmt.Errorf("RetrievePerson: failed creating http request: %w", err)
+	}
+
+	person := Person{}
+
+	err = c.makeRequest(request, &person)
+	if err != nil {
+		return Person{}, fmt.Errorf("RetrievePerson: failed executing http request: %w", err)
+	}
+
+	return person, nil
+}
@@ -4,11 +4,13 @@ import (
 	"bytes"
 	"context"
 	"encoding/binary"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -64,6 +66,44 @@ func Test_coordinate_UnmarshalJSON(t *testing.T) {
 	}
 }
 
+func TestPeople_decodeStream(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    People
+		wantErr bool
+	}{
+		{
+			"When passed array of people then each person is decoded",
+			`[{"id":1,"first_name":"Maurise","latitude":"34.003135"},{"id":2,"first_name":"Bendix"}]`,
+			People{{ID: 1, FirstName: "Maurise", Latitude: 34.003135}, {ID: 2, FirstName: "Bendix"}},
+			false,
+		},
+		{"When passed empty array then no people are decoded", `[]`, People{}, false},
+		{"When passed null then no people are decoded", `null`, People{}, false},
+		{"When passed object then error is returned", `{"id":1}`, People{}, true},
+		{"When passed unterminated array then error is returned", `[{"id":1}`, People{{ID: 1}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := People{}
+
+			if err := p.decodeStream(json.NewDecoder(strings.NewReader(`[{"id":3}]`))); err != nil {
+				t.Fatalf("decodeStream() error = %v", err)
+			}
+
+			if err := p.decodeStream(json.NewDecoder(strings.NewReader(tt.body))); (err != nil) != tt.wantErr {
+				t.Errorf("decodeStream() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(p, tt.want) {
+				t.Errorf("decodeStream() = %v, want %v", p, tt.want)
+			}
+		})
+	}
+}
+
 func Test_client_RetrievePeople(t *testing.T) {
 	t.Run("When request to RetrievePeople is successful then parse People are returned", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -130,8 +170,8 @@ func Test_client_RetrievePeople(t *testing.T) {
 			httpClient: http.Client{},
 		}
 
-		if _, err := c.RetrievePeople(context.Background()); err.Error() != "RetrievePeople: failed executing http request: request failed with status code 500 and body {}" {
-			t.Errorf("RetrievePeople() error = %v, want = RetrievePeople: failed executing http request: request failed with status code 500 and body {}", err)
+		if _, err := c.RetrievePeople(context.Background()); err.Error() != "RetrievePeople: failed executing http request: request to /users failed with status code 500 and body " {
+			t.Errorf("RetrievePeople() error = %v, want = RetrievePeople: failed executing http request: request to /users failed with status code 500 and body ", err)
 		}
 	})
 }
@@ -153,6 +193,22 @@ func Test_client_RetrievePeopleByCity(t *testing.T) {
 		c.RetrievePeopleByCity(context.Background(), "london") //nolint:errcheck
 	})
 
+	t.Run("When city contains a space then it is escaped in the URL path", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.EscapedPath() != "/city/New%20York/users" {
+				t.Errorf("RetrievePeopleByCity() %v, want = /city/New%%20York/users", r.URL.EscapedPath())
+			}
+		}))
+		defer server.Close()
+
+		c := &client{
+			baseURL:    server.URL,
+			httpClient: http.Client{},
+		}
+
+		c.RetrievePeopleByCity(context.Background(), "New York") //nolint:errcheck
+	})
+
 	t.Run("When request to RetrievePeopleByCity is successful then parse People are returned", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.Write([]byte("[{\"id\": 1, \"first_name\": \"Maurise\", \"last_name\": \"Shieldon\", \"email\": \"mshieldon0@squidoo.com\", \"ip_address\": \"192.57.232.111\", \"latitude\": 34.003135, \"longitude\": -117.7228641 }, { \"id\": 2, \"first_name\": \"Bendix\", \"last_name\": \"Halgarth\", \"email\": \"bhalgarth1@timesonline.co.uk\", \"ip_address\": \"4.185.73.82\", \"latitude\": -2.9623869, \"longitude\": 104.7399789}]")) //nolint:errcheck
@@ -218,8 +274,61 @@ func Test_client_RetrievePeopleByCity(t *testing.T) {
 			httpClient: http.Client{},
 		}
 
-		if _, err := c.RetrievePeopleByCity(context.Background(), "london"); err.Error() != "RetrievePeopleByCity: failed executing http request: request failed with status code 500 and body {}" {
-			t.Errorf("RetrievePeopleByCity() error = %v, want = RetrievePeopleByCity: failed executing http request: request failed with status code 500 and body {}", err)
+		if _, err := c.RetrievePeopleByCity(context.Background(), "london"); err.Error() != "RetrievePeopleByCity: failed executing http request: request to /city/london/users failed with status code 500 and body " {
+			t.Errorf("RetrievePeopleByCity() error = %v, want = RetrievePeopleByCity: failed executing http request: request to /city/london/users failed with status code 500 and body ", err)
+		}
+	})
+}
+
+func Test_client_RetrievePerson(t *testing.T) {
+	t.Run("When request to RetrievePerson is successful then parsed Person is returned", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/user/1" {
+				t.Errorf("RetrievePerson() %v, want = /user/1", r.URL.Path)
+			}
+
+			w.Write([]byte("{\"id\": 1, \"first_name\": \"Maurise\", \"last_name\": \"Shieldon\", \"email\": \"mshieldon0@squidoo.com\", \"ip_address\": \"192.57.232.111\", \"latitude\": 34.003135, \"longitude\": -117.7228641 }")) //nolint:errcheck
+		}))
+		defer server.Close()
+
+		c := &client{
+			baseURL:    server.URL,
+			httpClient: http.Client{},
+		}
+
+		p, err := c.RetrievePerson(context.Background(), 1)
+		if err != nil {
+			t.Errorf("RetrievePerson() error = %v", err)
+		}
+
+		e := Person{
+			ID:        1,
+			FirstName: "Maurise",
+			LastName:  "Shieldon",
+			Email:     "mshieldon0@squidoo.com",
+			IPAddress: "192.57.232.111",
+			Latitude:  Coordinate(34.003135),
+			Longitude: Coordinate(-117.7228641),
+		}
+
+		if !reflect.DeepEqual(p, e) {
+			t.Errorf("RetrievePerson() = %v, want %v", p, e)
+		}
+	})
+
+	t.Run("When server returns HTTP status 404 then not found error is returned", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		c := &client{
+			baseURL:    server.URL,
+			httpClient: http.Client{},
+		}
+
+		if _, err := c.RetrievePerson(context.Background(), 1001); !errors.Is(err, ErrNotFound) {
+			t.Errorf("RetrievePerson() error = %v, want %v", err, ErrNotFound)
 		}
 	})
 }
@@ -0,0 +1,163 @@
+package dwp
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// ErrorClass identifies a category of transport error that may be retried.
+type ErrorClass string
+
+const (
+	ErrorClassTimeout           ErrorClass = "timeout"
+	ErrorClassConnectionReset   ErrorClass = "connection-reset"
+	ErrorClassConnectionRefused ErrorClass = "connection-refused"
+	ErrorClassUnexpectedEOF     ErrorClass = "unexpected-eof"
+)
+
+// Valid reports whether c is one of the known error classes.
+func (c ErrorClass) Valid() bool {
+	switch c {
+	case ErrorClassTimeout, ErrorClassConnectionReset, ErrorClassConnectionRefused, ErrorClassUnexpectedEOF:
+		return true
+	}
+
+	return false
+}
+
+// matches reports whether err belongs to the error class.
+func (c ErrorClass) matches(err error) bool {
+	switch c {
+	case ErrorClassTimeout:
+		var netErr net.Error
+		return errors.As(err, &netErr) && netErr.Timeout()
+	case ErrorClassConnectionReset:
+		return errors.Is(err, syscall.ECONNRESET)
+	case ErrorClassConnectionRefused:
+		return errors.Is(err, syscall.ECONNREFUSED)
+	case ErrorClassUnexpectedEOF:
+		return errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF)
+	}
+
+	return false
+}
+
+// RetryPolicy configures how many times, and how often, a failed request is retried. The zero value makes a single
+// attempt.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first. Values below one are treated as one.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry. Each subsequent retry doubles the delay.
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between attempts. A zero value leaves the delay uncapped.
+	MaxDelay time.Duration
+	// Jitter is the fraction, between zero and one, of each delay that is randomised.
+	Jitter float64
+	// RetryableStatusCodes are the HTTP status codes that are retried.
+	RetryableStatusCodes []int
+	// RetryableErrors are the transport error classes that are retried.
+	RetryableErrors []ErrorClass
+}
+
+// WithRetryPolicy configures the Client to retry failed requests according to p.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *client) {
+		c.retryPolicy = p
+	}
+}
+
+// randFloat64 is used to randomise delays and is replaced in tests.
+var randFloat64 = rand.Float64 //nolint:gosec
+
+func (p RetryPolicy) attempts() int {
+	if p.MaxAttempts < 1 {
+		return 1
+	}
+
+	return p.MaxAttempts
+}
+
+func (p RetryPolicy) retryableStatusCode(statusCode int) bool {
+	for _, code := range p.RetryableStatusCodes {
+		if code == statusCode {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p RetryPolicy) retryableError(err error) bool {
+	for _, class := range p.RetryableErrors {
+		if class.matches(err) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// backoff returns the delay before the retry following the given attempt, counting from one.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := float64(p.BaseDelay) * math.Pow(2, float64(attempt-1)) //nolint:gomnd
+
+	if p.MaxDelay > 0 && delay > float64(p.MaxDelay) {
+		delay = float64(p.MaxDelay)
+	}
+
+	if p.Jitter > 0 {
+		delay -= delay * math.Min(p.Jitter, 1) * randFloat64()
+	}
+
+	return time.Duration(delay)
+}
+
+// retryAfter reports whether a retry may be made after the delay requested by a Retry-After header. A delay longer
+// than MaxDelay is not waited for, so that a single response cannot hold the request for as long as the upstream asks.
+func (p RetryPolicy) retryAfter(delay time.Duration) bool {
+	return p.MaxDelay <= 0 || delay <= p.MaxDelay
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which may be either a number of seconds or an HTTP date.
+// Zero is returned if the header is absent or invalid.
+func parseRetryAfter(h string, now time.Time) time.Duration {
+	if h == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(h); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if t, err := http.ParseTime(h); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+
+	return 0
+}
+
+// wait blocks for the delay or until ctx is done. If the delay would outlast the context deadline then false is
+// returned immediately, as there is no point in sleeping for an attempt that cannot be made.
+func wait(ctx context.Context, delay time.Duration) bool {
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+		return false
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,237 @@
+package dwp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestErrorClass_Valid(t *testing.T) {
+	tests := []struct {
+		name string
+		c    ErrorClass
+		want bool
+	}{
+		{"When error class is timeout then returns true", ErrorClassTimeout, true},
+		{"When error class is connection-reset then returns true", ErrorClassConnectionReset, true},
+		{"When error class is connection-refused then returns true", ErrorClassConnectionRefused, true},
+		{"When error class is unexpected-eof then returns true", ErrorClassUnexpectedEOF, true},
+		{"When error class is unknown then returns false", ErrorClass("not-a-class"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.Valid(); got != tt.want {
+				t.Errorf("Valid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorClass_matches(t *testing.T) {
+	tests := []struct {
+		name string
+		c    ErrorClass
+		err  error
+		want bool
+	}{
+		{"When error is a net.Error timeout then timeout matches", ErrorClassTimeout, &net.OpError{Err: timeoutError{}}, true},
+		{"When error is not a timeout then timeout does not match", ErrorClassTimeout, io.EOF, false},
+		{"When error wraps ECONNRESET then connection-reset matches", ErrorClassConnectionReset, fmt.Errorf("read: %w", syscall.ECONNRESET), true},
+		{"When error wraps ECONNREFUSED then connection-refused matches", ErrorClassConnectionRefused, fmt.Errorf("dial: %w", syscall.ECONNREFUSED), true},
+		{"When error wraps io.ErrUnexpectedEOF then unexpected-eof matches", ErrorClassUnexpectedEOF, fmt.Errorf("read: %w", io.ErrUnexpectedEOF), true},
+		{"When error class is unknown then does not match", ErrorClass("not-a-class"), io.EOF, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.matches(tt.err); got != tt.want {
+				t.Errorf("matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_attempts(t *testing.T) {
+	if got := (RetryPolicy{}).attempts(); got != 1 {
+		t.Errorf("attempts() = %v, want 1", got)
+	}
+
+	if got := (RetryPolicy{MaxAttempts: 3}).attempts(); got != 3 {
+		t.Errorf("attempts() = %v, want 3", got)
+	}
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	defer func(f func() float64) { randFloat64 = f }(randFloat64)
+
+	randFloat64 = func() float64 { return 1 }
+
+	tests := []struct {
+		name    string
+		p       RetryPolicy
+		attempt int
+		want    time.Duration
+	}{
+		{
+			"When first attempt then returns base delay",
+			RetryPolicy{BaseDelay: 100 * time.Millisecond},
+			1,
+			100 * time.Millisecond,
+		},
+		{
+			"When third attempt then returns base delay doubled twice",
+			RetryPolicy{BaseDelay: 100 * time.Millisecond},
+			3,
+			400 * time.Millisecond,
+		},
+		{
+			"When delay exceeds max delay then returns max delay",
+			RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: 250 * time.Millisecond},
+			3,
+			250 * time.Millisecond,
+		},
+		{
+			"When jitter is configured then delay is reduced by up to the jitter fraction",
+			RetryPolicy{BaseDelay: 100 * time.Millisecond, Jitter: 0.5},
+			1,
+			50 * time.Millisecond,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.backoff(tt.attempt); got != tt.want {
+				t.Errorf("backoff() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseRetryAfter(t *testing.T) {
+	now := time.Date(2022, 5, 19, 6, 53, 23, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		h    string
+		want time.Duration
+	}{
+		{"When header is empty then returns zero", "", 0},
+		{"When header is seconds then returns duration", "5", 5 * time.Second},
+		{"When header is HTTP date then returns duration until date", now.Add(time.Minute).Format(http.TimeFormat), time.Minute},
+		{"When header is HTTP date in the past then returns zero", now.Add(-time.Minute).Format(http.TimeFormat), 0},
+		{"When header is invalid then returns zero", "soon", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseRetryAfter(tt.h, now); got != tt.want {
+				t.Errorf("parseRetryAfter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_wait(t *testing.T) {
+	t.Run("When delay elapses then returns true", func(t *testing.T) {
+		if !wait(context.Background(), time.Millisecond) {
+			t.Errorf("wait() = false, want true")
+		}
+	})
+
+	t.Run("When delay outlasts context deadline then returns false", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		defer cancel()
+
+		if wait(ctx, time.Minute) {
+			t.Errorf("wait() = true, want false")
+		}
+	})
+
+	t.Run("When context is cancelled then returns false", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		if wait(ctx, time.Minute) {
+			t.Errorf("wait() = true, want false")
+		}
+	})
+}
+
+func TestRetryPolicy_retryAfter(t *testing.T) {
+	tests := []struct {
+		name     string
+		maxDelay time.Duration
+		delay    time.Duration
+		want     bool
+	}{
+		{"When delay is within MaxDelay then returns true", 2 * time.Second, time.Second, true},
+		{"When delay equals MaxDelay then returns true", 2 * time.Second, 2 * time.Second, true},
+		{"When delay exceeds MaxDelay then returns false", 2 * time.Second, time.Hour, false},
+		{"When MaxDelay is zero then returns true", 0, time.Hour, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (RetryPolicy{MaxDelay: tt.maxDelay}).retryAfter(tt.delay); got != tt.want {
+				t.Errorf("retryAfter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_client_makeRequest_retryAfterExceedsMaxDelay(t *testing.T) {
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	c := client{
+		baseURL:    server.URL,
+		httpClient: *server.Client(),
+		retryPolicy: RetryPolicy{
+			MaxAttempts:          3,
+			BaseDelay:            time.Millisecond,
+			MaxDelay:             2 * time.Second,
+			RetryableStatusCodes: []int{http.StatusServiceUnavailable},
+		},
+	}
+
+	// The request context has no deadline, so only the policy can stop the client waiting for an hour.
+	r, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+"/test-path", nil)
+
+	start := time.Now()
+	err := c.makeRequest(r, nil)
+
+	var apiError *APIError
+
+	if !errors.As(err, &apiError) || apiError.RetryAfter != time.Hour {
+		t.Errorf("makeRequest() error = %v, want *APIError with RetryAfter 1h", err)
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("makeRequest() calls = %v, want 1", got)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("makeRequest() took %v, want no wait", elapsed)
+	}
+}
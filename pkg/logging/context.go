@@ -0,0 +1,24 @@
+package logging
+
+import "context"
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying the fields, such as fields identifying a request, which are added to the
+// Loggers returned by FromContext.
+func NewContext(ctx context.Context, fields Fields) context.Context {
+	if carried, ok := ctx.Value(contextKey{}).(Fields); ok {
+		fields = carried.merge(fields)
+	}
+
+	return context.WithValue(ctx, contextKey{}, fields)
+}
+
+// FromContext returns l with the fields carried by ctx added, or l if ctx does not carry any fields.
+func FromContext(ctx context.Context, l Logger) Logger {
+	if fields, ok := ctx.Value(contextKey{}).(Fields); ok {
+		return l.With(fields)
+	}
+
+	return l
+}
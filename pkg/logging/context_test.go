@@ -0,0 +1,33 @@
+package logging
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestFromContext(t *testing.T) {
+	t.Run("Given a context without fields then the Logger is returned", func(t *testing.T) {
+		s := captureLogs(func() {
+			l := New(Info).Component("test")
+			FromContext(context.Background(), l).Info("test info message")
+		})
+
+		if !strings.Contains(s, "test info message component=test\n") {
+			t.Errorf("FromContext() = %v, want test info message component=test", s)
+		}
+	})
+
+	t.Run("Given a context with fields then the fields are added to the Logger", func(t *testing.T) {
+		s := captureLogs(func() {
+			ctx := NewContext(context.Background(), Fields{"requestId": "test-request-id"})
+			ctx = NewContext(ctx, Fields{"user": "test-user"})
+
+			FromContext(ctx, New(Info).Component("test")).Info("test info message")
+		})
+
+		if !strings.Contains(s, "test info message component=test requestId=test-request-id user=test-user\n") {
+			t.Errorf("FromContext() = %v, want fields from context", s)
+		}
+	})
+}
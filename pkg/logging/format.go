@@ -0,0 +1,185 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format is the format in which log entries are written.
+type Format int
+
+const (
+	// FormatText writes each log entry as a line of text prefixed with its level, date and time.
+	FormatText Format = iota
+	// FormatJSON writes each log entry as a JSON object on its own line.
+	FormatJSON
+)
+
+func (f Format) String() string {
+	switch f {
+	case FormatText:
+		return "text"
+	case FormatJSON:
+		return "json"
+	}
+
+	return ""
+}
+
+func (f *Format) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("Format.UnmarshalJSON: failed to unmarshal: %w", err)
+	}
+
+	format, err := stringToFormat(s)
+	if err != nil {
+		return fmt.Errorf("Format.UnmarshalJSON: failed to unmarshal: %w", err)
+	}
+
+	*f = format
+
+	return nil
+}
+
+func (f *Format) UnmarshalYAML(n *yaml.Node) error {
+	format, err := stringToFormat(n.Value)
+	if err != nil {
+		return fmt.Errorf("Format.UnmarshalYAML: failed to unmarshal: %w", err)
+	}
+
+	*f = format
+
+	return nil
+}
+
+func stringToFormat(s string) (Format, error) {
+	switch s {
+	case "text":
+		return FormatText, nil
+	case "json":
+		return FormatJSON, nil
+	}
+
+	return 0, fmt.Errorf("%s is not a valid log format - valid options are text or json", s)
+}
+
+// Fields are key/value pairs added to log entries.
+type Fields map[string]any
+
+// reservedKeys are the keys of every JSON log entry, which fields cannot replace.
+var reservedKeys = map[string]bool{"timestamp": true, "level": true, "message": true, "caller": true}
+
+// merge returns the fields together with other, whose values take precedence.
+func (f Fields) merge(other Fields) Fields {
+	merged := make(Fields, len(f)+len(other))
+
+	for k, v := range f {
+		merged[k] = v
+	}
+
+	for k, v := range other {
+		merged[k] = v
+	}
+
+	return merged
+}
+
+// keys returns the keys of the fields in order.
+func (f Fields) keys() []string {
+	keys := make([]string, 0, len(f))
+
+	for k := range f {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// text returns the fields as space separated key=value pairs, each preceded by a space.
+func (f Fields) text() string {
+	var b strings.Builder
+
+	for _, k := range f.keys() {
+		fmt.Fprintf(&b, " %s=%v", k, f[k])
+	}
+
+	return b.String()
+}
+
+// jsonEntry returns the log entry as a JSON object. The timestamp, level, message and caller are followed by the fields
+// in order.
+func jsonEntry(timestamp time.Time, level Level, logMessage any, caller string, fields Fields) string {
+	var b bytes.Buffer
+
+	b.WriteByte('{')
+
+	writeJSONMember(&b, "timestamp", timestamp.UTC().Format(time.RFC3339Nano))
+	writeJSONMember(&b, "level", level.String())
+	writeJSONMember(&b, "message", message(logMessage))
+
+	if caller != "" {
+		writeJSONMember(&b, "caller", caller)
+	}
+
+	for _, k := range fields.keys() {
+		if !reservedKeys[k] {
+			writeJSONMember(&b, k, fields[k])
+		}
+	}
+
+	b.WriteByte('}')
+
+	return b.String()
+}
+
+func writeJSONMember(b *bytes.Buffer, key string, value any) {
+	if b.Len() > 1 {
+		b.WriteByte(',')
+	}
+
+	k, _ := json.Marshal(key)
+	b.Write(k)
+	b.WriteByte(':')
+
+	if err, ok := value.(error); ok {
+		value = err.Error()
+	}
+
+	v, err := json.Marshal(value)
+	if err != nil {
+		v, _ = json.Marshal(fmt.Sprint(value))
+	}
+
+	b.Write(v)
+}
+
+// message returns the log message as a string.
+func message(logMessage any) string {
+	if err, ok := logMessage.(error); ok {
+		return err.Error()
+	}
+
+	return fmt.Sprint(logMessage)
+}
+
+// caller returns the file and line of the function skip frames above the caller of caller, as the file's directory
+// and name followed by the line number.
+func caller(skip int) string {
+	_, file, line, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return ""
+	}
+
+	return fmt.Sprintf("%s/%s:%d", filepath.Base(filepath.Dir(file)), filepath.Base(file), line)
+}
@@ -0,0 +1,107 @@
+package logging
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestFormat_String(t *testing.T) {
+	tests := []struct {
+		f    Format
+		want string
+	}{
+		{FormatText, "text"},
+		{FormatJSON, "json"},
+		{Format(2), ""},
+	}
+
+	for _, tt := range tests {
+		if got := tt.f.String(); got != tt.want {
+			t.Errorf("String() = %v, want %v", got, tt.want)
+		}
+	}
+}
+
+func TestFormat_UnmarshalYAML(t *testing.T) {
+	var f Format
+
+	if err := yaml.Unmarshal([]byte("json"), &f); err != nil || f != FormatJSON {
+		t.Errorf("UnmarshalYAML() = %v, %v, want json", f, err)
+	}
+
+	if err := yaml.Unmarshal([]byte("xml"), &f); err == nil {
+		t.Errorf("UnmarshalYAML() error = nil, want error")
+	}
+}
+
+func TestFormat_UnmarshalJSON(t *testing.T) {
+	var f Format
+
+	if err := json.Unmarshal([]byte(`"json"`), &f); err != nil || f != FormatJSON {
+		t.Errorf("UnmarshalJSON() = %v, %v, want json", f, err)
+	}
+
+	if err := json.Unmarshal([]byte(`"xml"`), &f); err == nil {
+		t.Errorf("UnmarshalJSON() error = nil, want error")
+	}
+}
+
+func Test_jsonEntry(t *testing.T) {
+	timestamp := time.Date(2022, 3, 12, 15, 21, 34, 0, time.UTC)
+	fields := Fields{"status": 404, "path": "/api/people/atlantis", "level": "overridden", "err": errors.New("test error")}
+
+	got := jsonEntry(timestamp, Info, "city not found", "handler/handlers.go:42", fields)
+
+	want := `{"timestamp":"2022-03-12T15:21:34Z","level":"info","message":"city not found","caller":"handler/handlers.go:42",` +
+		`"err":"test error","path":"/api/people/atlantis","status":404}`
+
+	if got != want {
+		t.Errorf("jsonEntry() = %v, want %v", got, want)
+	}
+}
+
+func Test_logger_With(t *testing.T) {
+	t.Run("When format is JSON then each log entry is a JSON object with fields and caller", func(t *testing.T) {
+		s := captureLogs(func() {
+			l := New(Info, WithFormat(FormatJSON)).With(Fields{"component": "test"})
+			l.With(Fields{"request": 1}).Info("test info message")
+		})
+
+		lines := strings.Split(strings.TrimSpace(s), "\n")
+
+		var entry map[string]any
+
+		if err := json.Unmarshal([]byte(lines[len(lines)-1]), &entry); err != nil {
+			t.Fatalf("Info() = %v, want JSON: %v", s, err)
+		}
+
+		if entry["message"] != "test info message" || entry["level"] != "info" || entry["component"] != "test" || entry["request"] != 1.0 {
+			t.Errorf("Info() = %v, want message, level and fields", entry)
+		}
+
+		if caller, _ := entry["caller"].(string); !strings.HasPrefix(caller, "logging/format_test.go:") {
+			t.Errorf("Info() caller = %v, want logging/format_test.go", entry["caller"])
+		}
+	})
+
+	t.Run("When format is text then fields are appended as key value pairs", func(t *testing.T) {
+		s := captureLogs(func() {
+			l := New(Error)
+			l.With(Fields{"b": 2, "a": "x"}).Error("test error message")
+			l.Error("without fields")
+		})
+
+		if !strings.Contains(s, "test error message a=x b=2\n") {
+			t.Errorf("Error() = %v, want test error message a=x b=2", s)
+		}
+
+		if !strings.Contains(s, "without fields\n") {
+			t.Errorf("Error() = %v, want fields not to be added to the parent logger", s)
+		}
+	})
+}
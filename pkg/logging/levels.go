@@ -0,0 +1,136 @@
+package logging
+
+import (
+	"sort"
+	"sync"
+)
+
+// Levels are the levels of a Logger and every Logger derived from it, which may be changed while they are in use. The
+// level of a component overrides the level for the Loggers of that component.
+type Levels struct {
+	mu         sync.RWMutex
+	level      Level
+	components map[string]Level
+	// logger logs each change of level, whatever the level.
+	logger *logger
+}
+
+// ParseLevel parses a log level. Valid options are fatal, error, warn, info, debug or trace.
+func ParseLevel(s string) (Level, error) {
+	return stringToLevel(s)
+}
+
+// Level returns the level of Loggers whose component has no level of its own.
+func (l *Levels) Level() Level {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return l.level
+}
+
+// Components returns the level of each component that has a level of its own.
+func (l *Levels) Components() map[string]Level {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	components := make(map[string]Level, len(l.components))
+
+	for component, level := range l.components {
+		components[component] = level
+	}
+
+	return components
+}
+
+// SetLevel changes the level of Loggers whose component has no level of its own.
+func (l *Levels) SetLevel(level Level) {
+	l.mu.Lock()
+	from := l.level
+	l.level = level
+	l.mu.Unlock()
+
+	l.logChange("", from, level)
+}
+
+// SetComponentLevel changes the level of the component's Loggers.
+func (l *Levels) SetComponentLevel(component string, level Level) {
+	l.mu.Lock()
+	from, ok := l.components[component]
+
+	if !ok {
+		from = l.level
+	}
+
+	l.components[component] = level
+	l.mu.Unlock()
+
+	l.logChange(component, from, level)
+}
+
+// ResetComponentLevel removes the level of the component, so that its Loggers use the level of Levels.
+func (l *Levels) ResetComponentLevel(component string) {
+	l.mu.Lock()
+	from, ok := l.components[component]
+	to := l.level
+
+	delete(l.components, component)
+	l.mu.Unlock()
+
+	if ok {
+		l.logChange(component, from, to)
+	}
+}
+
+// Reset changes the level to level and removes the level of every component.
+func (l *Levels) Reset(level Level) {
+	l.mu.Lock()
+	from := l.level
+	components := l.components
+
+	l.level = level
+	l.components = make(map[string]Level)
+	l.mu.Unlock()
+
+	l.logChange("", from, level)
+
+	names := make([]string, 0, len(components))
+
+	for component := range components {
+		names = append(names, component)
+	}
+
+	sort.Strings(names)
+
+	for _, component := range names {
+		l.logChange(component, components[component], level)
+	}
+}
+
+// enabled reports whether log entries of the level are written by Loggers of the component.
+func (l *Levels) enabled(component string, level Level) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if componentLevel, ok := l.components[component]; ok && component != "" {
+		return level <= componentLevel
+	}
+
+	return level <= l.level
+}
+
+func (l *Levels) logChange(component string, from Level, to Level) {
+	if l.logger == nil {
+		return
+	}
+
+	fields := Fields{"from": from.String(), "to": to.String()}
+
+	if component != "" {
+		fields["component"] = component
+	}
+
+	lo := *l.logger
+	lo.fields = lo.fields.merge(fields)
+
+	lo.write(Info, "log level changed")
+}
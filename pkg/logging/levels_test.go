@@ -0,0 +1,110 @@
+package logging
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseLevel(t *testing.T) {
+	if got, err := ParseLevel("debug"); err != nil || got != Debug {
+		t.Errorf("ParseLevel() = %v, %v, want debug", got, err)
+	}
+
+	if _, err := ParseLevel("verbose"); err == nil {
+		t.Errorf("ParseLevel() error = nil, want error")
+	}
+}
+
+func TestLevels_SetLevel(t *testing.T) {
+	s := captureLogs(func() {
+		l := New(Error)
+		l.Info("before change")
+
+		l.Levels().SetLevel(Info)
+		l.With(Fields{"derived": true}).Info("after change")
+	})
+
+	if strings.Contains(s, "before change") {
+		t.Errorf("SetLevel() = %v, want info not logged before change", s)
+	}
+
+	if !strings.Contains(s, "log level changed from=error to=info\n") {
+		t.Errorf("SetLevel() = %v, want change logged", s)
+	}
+
+	if !strings.Contains(s, "after change derived=true\n") {
+		t.Errorf("SetLevel() = %v, want derived Logger to use changed level", s)
+	}
+}
+
+func TestLevels_SetComponentLevel(t *testing.T) {
+	s := captureLogs(func() {
+		l := New(Info)
+		people := l.Component("people")
+
+		l.Levels().SetComponentLevel("people", Debug)
+		people.Debug("people debug message")
+		l.Component("handler").Debug("handler debug message")
+
+		l.Levels().ResetComponentLevel("people")
+		people.Debug("reset debug message")
+	})
+
+	if !strings.Contains(s, "log level changed component=people from=info to=debug\n") {
+		t.Errorf("SetComponentLevel() = %v, want change logged", s)
+	}
+
+	if !strings.Contains(s, "people debug message component=people\n") {
+		t.Errorf("SetComponentLevel() = %v, want people debug message", s)
+	}
+
+	if strings.Contains(s, "handler debug message") {
+		t.Errorf("SetComponentLevel() = %v, want handler debug message not logged", s)
+	}
+
+	if !strings.Contains(s, "log level changed component=people from=debug to=info\n") {
+		t.Errorf("ResetComponentLevel() = %v, want change logged", s)
+	}
+
+	if strings.Contains(s, "reset debug message") {
+		t.Errorf("ResetComponentLevel() = %v, want reset debug message not logged", s)
+	}
+}
+
+func TestLevels_Reset(t *testing.T) {
+	levels := &Levels{level: Debug, components: map[string]Level{"people": Error}}
+
+	levels.Reset(Info)
+
+	if got := levels.Level(); got != Info {
+		t.Errorf("Reset() level = %v, want info", got)
+	}
+
+	if got := levels.Components(); !reflect.DeepEqual(got, map[string]Level{}) {
+		t.Errorf("Reset() components = %v, want none", got)
+	}
+}
+
+func TestLevels_enabled(t *testing.T) {
+	levels := &Levels{level: Info, components: map[string]Level{"people": Debug, "handler": Error}}
+
+	tests := []struct {
+		component string
+		level     Level
+		want      bool
+	}{
+		{"", Info, true},
+		{"", Debug, false},
+		{"people", Debug, true},
+		{"handler", Info, false},
+		{"handler", Error, true},
+		{"access", Info, true},
+	}
+
+	for _, tt := range tests {
+		if got := levels.enabled(tt.component, tt.level); got != tt.want {
+			t.Errorf("enabled(%q, %v) = %v, want %v", tt.component, tt.level, got, tt.want)
+		}
+	}
+}
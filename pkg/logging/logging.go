@@ -1,38 +1,69 @@
-// Package logging provides a succinct logger that supports log levels. The logger is preconfigured to output log entry's
-// with time, date and log level prefixes.
+// Package logging provides a succinct logger that supports log levels and fields. The logger is preconfigured to output
+// log entry's with time, date and log level prefixes, or can instead output each log entry as a JSON object.
 package logging
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
 type Level int
 
+// Error is the zero Level, so that error log entries are written by a Logger whose level is not configured.
 const (
-	Error Level = iota
+	Fatal Level = iota - 1
+	Error
+	Warn
 	Info
 	Debug
+	Trace
 )
 
+// levelCount is the number of levels.
+const levelCount = int(Trace-Fatal) + 1
+
+// index returns the position of the level among the levels, from zero for Fatal.
+func (l Level) index() int {
+	return int(l - Fatal)
+}
+
 func (l Level) String() string {
 	switch l {
+	case Fatal:
+		return "fatal"
 	case Error:
 		return "error"
+	case Warn:
+		return "warn"
 	case Info:
 		return "info"
 	case Debug:
 		return "debug"
+	case Trace:
+		return "trace"
 	}
 
 	return ""
 }
 
+// UnmarshalJSON unmarshals a level from a JSON string, or from the level's name without quotes.
 func (l *Level) UnmarshalJSON(b []byte) error {
-	level, err := stringToLevel(string(b))
+	s := string(b)
+
+	if strings.HasPrefix(s, `"`) {
+		if err := json.Unmarshal(b, &s); err != nil {
+			return fmt.Errorf("Level.UnmarshalJSON: failed to unmarshal: %w", err)
+		}
+	}
+
+	level, err := stringToLevel(s)
 	if err != nil {
 		return fmt.Errorf("Level.UnmarshalJSON: failed to unmarshal: %w", err)
 	}
@@ -55,60 +86,206 @@ func (l *Level) UnmarshalYAML(n *yaml.Node) error {
 
 func stringToLevel(s string) (Level, error) {
 	switch s {
+	case "fatal":
+		return Fatal, nil
 	case "error":
 		return Error, nil
+	case "warn":
+		return Warn, nil
 	case "info":
 		return Info, nil
 	case "debug":
 		return Debug, nil
+	case "trace":
+		return Trace, nil
 	}
 
-	return 0, fmt.Errorf("%s is not a valid log level - valid options are error, info or debug", s)
+	return 0, fmt.Errorf("%s is not a valid log level - valid options are fatal, error, warn, info, debug or trace", s)
 }
 
 type Logger interface {
+	// Fatal logs the message, whatever the level, and then exits the process with status 1.
+	Fatal(logMessage any)
 	Error(logMessage any)
+	Warn(logMessage any)
 	Info(logMessage any)
 	Debug(logMessage any)
+	Trace(logMessage any)
+	// Fatalf formats the message according to the format specifier, logs it, whatever the level, and then exits the
+	// process with status 1.
+	Fatalf(format string, args ...any)
+	Errorf(format string, args ...any)
+	Warnf(format string, args ...any)
+	Infof(format string, args ...any)
+	Debugf(format string, args ...any)
+	Tracef(format string, args ...any)
+	// Enabled reports whether log entries of the level are written by the Logger.
+	Enabled(level Level) bool
+	// With returns a Logger that adds the fields to every log entry, in addition to any fields already added.
+	With(fields Fields) Logger
+	// Component returns a Logger for the component, such as a package, whose level may be changed independently. The
+	// component is added to every log entry as the component field.
+	Component(name string) Logger
+	// Levels returns the levels shared by the Logger and every Logger derived from it, which may be changed while they
+	// are in use.
+	Levels() *Levels
+}
+
+// Option configures optional behaviour of the Logger returned by New.
+type Option func(*logger)
+
+// WithFormat configures the Logger to write log entries in the format.
+func WithFormat(f Format) Option {
+	return func(l *logger) {
+		l.format = f
+	}
 }
 
+// WithWriter configures the Logger to write log entries of the level to w. By default, fatal, error and warn log
+// entries are written to Stderr and all other log entries to Stdout.
+func WithWriter(level Level, w io.Writer) Option {
+	return func(l *logger) {
+		l.writers[level.index()] = w
+	}
+}
+
+// callerSkip is the number of frames between write and the caller of the Logger.
+const callerSkip = 3
+
 type logger struct {
-	infoLog  *log.Logger
-	errorLog *log.Logger
-	debugLog *log.Logger
-	level    Level
+	outputs   [levelCount]*log.Logger
+	writers   [levelCount]io.Writer
+	levels    *Levels
+	component string
+	format    Format
+	fields    Fields
+	now       func() time.Time
+	exit      func(code int)
 }
 
-// New returns an instance of Logger configured to output log entry of the passed Level or higher.
-func New(l Level) Logger {
-	infoLog := log.New(os.Stdout, "INFO\t", log.Ldate|log.Ltime|log.Lmicroseconds)
-	errorLog := log.New(os.Stderr, "ERROR\t", log.Ldate|log.Ltime|log.Lmicroseconds)
-	debugLog := log.New(os.Stdout, "DEBUG\t", log.Ldate|log.Ltime|log.Lmicroseconds)
+// New returns an instance of Logger configured to output log entry of the passed Level or higher. Log entries are
+// written as text unless another format is configured.
+func New(l Level, options ...Option) Logger {
+	lo := &logger{levels: &Levels{level: l, components: make(map[string]Level)}, now: time.Now, exit: os.Exit}
 
-	lo := &logger{
-		infoLog, errorLog, debugLog, l,
+	for level := Fatal; level <= Trace; level++ {
+		lo.writers[level.index()] = os.Stdout
+
+		if level <= Warn {
+			lo.writers[level.index()] = os.Stderr
+		}
+	}
+
+	for _, option := range options {
+		option(lo)
 	}
 
-	lo.Info(fmt.Sprintf("Creating Logger with log level: %s", l))
+	for i, w := range lo.writers {
+		if lo.format == FormatJSON {
+			lo.outputs[i] = log.New(w, "", 0)
+		} else {
+			lo.outputs[i] = log.New(w, strings.ToUpper((Fatal+Level(i)).String())+"\t", log.Ldate|log.Ltime|log.Lmicroseconds)
+		}
+	}
+
+	lo.levels.logger = lo
+
+	lo.Infof("Creating Logger with log level: %s", l)
 
 	return lo
 }
 
+// Fatal prints message to Stderr and exits
+func (l logger) Fatal(logMessage any) {
+	l.log(Fatal, logMessage)
+	l.exit(1)
+}
+
 // Error prints message to Stderr
 func (l logger) Error(logMessage any) {
-	l.errorLog.Println(logMessage)
+	l.log(Error, logMessage)
+}
+
+// Warn prints message to Stderr
+func (l logger) Warn(logMessage any) {
+	l.log(Warn, logMessage)
 }
 
 // Info prints message to Stdout
 func (l logger) Info(logMessage any) {
-	if l.level > 0 {
-		l.infoLog.Println(logMessage)
-	}
+	l.log(Info, logMessage)
 }
 
 // Debug prints message to Stdout
 func (l logger) Debug(logMessage any) {
-	if l.level > 1 {
-		l.debugLog.Println(logMessage)
+	l.log(Debug, logMessage)
+}
+
+// Trace prints message to Stdout
+func (l logger) Trace(logMessage any) {
+	l.log(Trace, logMessage)
+}
+
+func (l logger) Fatalf(format string, args ...any) {
+	l.log(Fatal, fmt.Sprintf(format, args...))
+	l.exit(1)
+}
+
+func (l logger) Errorf(format string, args ...any) {
+	l.log(Error, fmt.Sprintf(format, args...))
+}
+
+func (l logger) Warnf(format string, args ...any) {
+	l.log(Warn, fmt.Sprintf(format, args...))
+}
+
+func (l logger) Infof(format string, args ...any) {
+	l.log(Info, fmt.Sprintf(format, args...))
+}
+
+func (l logger) Debugf(format string, args ...any) {
+	l.log(Debug, fmt.Sprintf(format, args...))
+}
+
+func (l logger) Tracef(format string, args ...any) {
+	l.log(Trace, fmt.Sprintf(format, args...))
+}
+
+func (l logger) Enabled(level Level) bool {
+	return l.levels.enabled(l.component, level)
+}
+
+func (l logger) With(fields Fields) Logger {
+	l.fields = l.fields.merge(fields)
+
+	return l
+}
+
+func (l logger) Component(name string) Logger {
+	l.component = name
+	l.fields = l.fields.merge(Fields{"component": name})
+
+	return l
+}
+
+func (l logger) Levels() *Levels {
+	return l.levels
+}
+
+// log writes the log entry if the level is enabled for the Logger's component.
+func (l logger) log(level Level, logMessage any) {
+	if l.Enabled(level) {
+		l.write(level, logMessage)
 	}
 }
+
+func (l logger) write(level Level, logMessage any) {
+	out := l.outputs[level.index()]
+
+	if l.format == FormatJSON {
+		out.Print(jsonEntry(l.now(), level, logMessage, caller(callerSkip), l.fields))
+		return
+	}
+
+	out.Print(message(logMessage) + l.fields.text())
+}
@@ -21,6 +21,16 @@ func TestLevel_String(t *testing.T) {
 			Error,
 			"error",
 		},
+		{
+			"When level is Fatal then returns fatal",
+			Fatal,
+			"fatal",
+		},
+		{
+			"When level is Warn then returns warn",
+			Warn,
+			"warn",
+		},
 		{
 			"When level is Info then returns info",
 			Info,
@@ -32,8 +42,13 @@ func TestLevel_String(t *testing.T) {
 			"debug",
 		},
 		{
-			"When level is resolved value > 2 then returns nil string",
-			Level(3),
+			"When level is Trace then returns trace",
+			Trace,
+			"trace",
+		},
+		{
+			"When level is resolved value > 5 then returns nil string",
+			Level(6),
 			"",
 		},
 	}
@@ -60,11 +75,17 @@ func TestLevel_UnmarshalJSON(t *testing.T) {
 			Info,
 			nil,
 		},
+		{
+			"When passed valid Level JSON string as byte array then sets level",
+			[]byte(`"warn"`),
+			Warn,
+			nil,
+		},
 		{
 			"When passed invalid Level string as byte array then returns error",
 			[]byte("Not a valid level"),
 			0,
-			errors.New("Level.UnmarshalJSON: failed to unmarshal: Not a valid level is not a valid log level - valid options are error, info or debug"),
+			errors.New("Level.UnmarshalJSON: failed to unmarshal: Not a valid level is not a valid log level - valid options are fatal, error, warn, info, debug or trace"),
 		},
 	}
 
@@ -97,11 +118,17 @@ func TestLevel_UnmarshalYAML(t *testing.T) {
 			Info,
 			nil,
 		},
+		{
+			"When passed trace Level string as *yaml.Node then sets level",
+			&yaml.Node{Value: "trace"},
+			Trace,
+			nil,
+		},
 		{
 			"When passed invalid Level string as *yaml.Node then returns error",
 			&yaml.Node{Value: "Not a valid level"},
 			0,
-			errors.New("Level.UnmarshalYAML: failed to unmarshal: Not a valid level is not a valid log level - valid options are error, info or debug"),
+			errors.New("Level.UnmarshalYAML: failed to unmarshal: Not a valid level is not a valid log level - valid options are fatal, error, warn, info, debug or trace"),
 		},
 	}
 
@@ -138,6 +165,18 @@ func Test_stringToLevel(t *testing.T) {
 			Error,
 			false,
 		},
+		{
+			"When passed fatal then returns Fatal level",
+			args{"fatal"},
+			Fatal,
+			false,
+		},
+		{
+			"When passed warn then returns Warn level",
+			args{"warn"},
+			Warn,
+			false,
+		},
 		{
 			"When passed info then returns Info level",
 			args{"info"},
@@ -150,6 +189,12 @@ func Test_stringToLevel(t *testing.T) {
 			Debug,
 			false,
 		},
+		{
+			"When passed trace then returns Trace level",
+			args{"trace"},
+			Trace,
+			false,
+		},
 		{
 			"When passed invalid level then returns error",
 			args{"Not a valid level"},
@@ -173,8 +218,8 @@ func Test_stringToLevel(t *testing.T) {
 }
 
 func TestNew(t *testing.T) {
-	if got := New(Info).(*logger); got.level != 1 {
-		t.Errorf("New() = %v, want info", got.level)
+	if got := New(Info).(*logger); got.levels.Level() != Info {
+		t.Errorf("New() = %v, want info", got.levels.Level())
 	}
 }
 
@@ -251,3 +296,147 @@ func Test_logger_Debug(t *testing.T) {
 		}
 	})
 }
+
+func Test_logger_Warn(t *testing.T) {
+	t.Run("When logger level is Warn then log message is printed", func(t *testing.T) {
+		s := captureLogs(func() {
+			l := New(Warn)
+			l.Warn("test warn message")
+			l.Info("test info message")
+		})
+
+		if !strings.Contains(s, "WARN\t") || !strings.Contains(s, "test warn message") {
+			t.Errorf("Warn() = %v, want test warn message", s)
+		}
+
+		if strings.Contains(s, "test info message") {
+			t.Errorf("Info() = %v, want no info message", s)
+		}
+	})
+
+	t.Run("When log level is Error then no message is printed", func(t *testing.T) {
+		s := captureLogs(func() {
+			l := New(Error)
+			l.Warn("test warn message")
+		})
+
+		if s != "" {
+			t.Errorf("Warn() = %v, want \"\"", s)
+		}
+	})
+}
+
+func Test_logger_Trace(t *testing.T) {
+	t.Run("When logger level is Trace then log message is printed to Stout", func(t *testing.T) {
+		s := captureLogs(func() {
+			l := New(Trace)
+			l.Trace("test trace message")
+		})
+
+		if !strings.Contains(s, "TRACE\t") || !strings.Contains(s, "test trace message") {
+			t.Errorf("Trace() = %v, want test trace message", s)
+		}
+	})
+
+	t.Run("When log level is Debug then no message is printed to Stout", func(t *testing.T) {
+		s := captureLogs(func() {
+			l := New(Debug)
+			l.Trace("test trace message")
+		})
+
+		if strings.Contains(s, "test trace message") {
+			t.Errorf("Trace() = %v, want no trace message", s)
+		}
+	})
+}
+
+func Test_logger_zeroLevel(t *testing.T) {
+	var level Level
+
+	s := captureLogs(func() {
+		l := New(level)
+		l.Error("test error message")
+		l.Warn("test warn message")
+	})
+
+	if level != Error {
+		t.Errorf("zero Level = %v, want error", level)
+	}
+
+	if !strings.Contains(s, "test error message") || strings.Contains(s, "test warn message") {
+		t.Errorf("New(0) = %v, want only test error message", s)
+	}
+}
+
+func Test_logger_formatted(t *testing.T) {
+	s := captureLogs(func() {
+		l := New(Trace)
+		l.Errorf("test %s message", "error")
+		l.Warnf("test %s message", "warn")
+		l.Infof("test %s message", "info")
+		l.Debugf("test %s message", "debug")
+		l.Tracef("test %s message %d", "trace", 1)
+	})
+
+	for _, want := range []string{"test error message", "test warn message", "test info message", "test debug message", "test trace message 1"} {
+		if !strings.Contains(s, want+"\n") {
+			t.Errorf("logger = %v, want %v", s, want)
+		}
+	}
+}
+
+func Test_logger_Fatal(t *testing.T) {
+	var code int
+
+	s := captureLogs(func() {
+		l := New(Error).(*logger)
+		l.exit = func(c int) { code = c }
+
+		l.Fatal("test fatal message")
+		l.Fatalf("test fatal message %d", 2)
+	})
+
+	if !strings.Contains(s, "FATAL\t") || !strings.Contains(s, "test fatal message\n") || !strings.Contains(s, "test fatal message 2\n") {
+		t.Errorf("Fatal() = %v, want test fatal messages", s)
+	}
+
+	if code != 1 {
+		t.Errorf("Fatal() exit code = %v, want 1", code)
+	}
+}
+
+func Test_logger_Enabled(t *testing.T) {
+	l := New(Info)
+	l.Levels().SetComponentLevel("access", Error)
+
+	if !l.Enabled(Info) || l.Enabled(Debug) {
+		t.Errorf("Enabled() = %v %v, want true false", l.Enabled(Info), l.Enabled(Debug))
+	}
+
+	if access := l.Component("access"); access.Enabled(Info) || !access.Enabled(Error) {
+		t.Errorf("Enabled() component = %v %v, want false true", access.Enabled(Info), access.Enabled(Error))
+	}
+}
+
+func TestWithWriter(t *testing.T) {
+	var errorWriter, debugWriter strings.Builder
+
+	s := captureLogs(func() {
+		l := New(Debug, WithFormat(FormatJSON), WithWriter(Error, &errorWriter), WithWriter(Debug, &debugWriter))
+		l.Errorf("test %s message", "error")
+		l.Debug("test debug message")
+		l.Info("test info message")
+	})
+
+	if !strings.Contains(errorWriter.String(), `"level":"error","message":"test error message","caller":"logging/logging_test.go:`) {
+		t.Errorf("WithWriter() error = %v, want test error message", errorWriter.String())
+	}
+
+	if !strings.Contains(debugWriter.String(), `"level":"debug","message":"test debug message"`) || strings.Contains(debugWriter.String(), "info") {
+		t.Errorf("WithWriter() debug = %v, want test debug message only", debugWriter.String())
+	}
+
+	if strings.Contains(s, "test error message") || !strings.Contains(s, "test info message") {
+		t.Errorf("WithWriter() = %v, want only test info message", s)
+	}
+}
@@ -0,0 +1,46 @@
+//go:build !windows
+
+package logging
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// NotifySignals changes the levels when the process is signalled, until ctx is done. SIGUSR1 raises the level to the
+// next level, from error to warn, info, debug and trace and back to error, and SIGHUP resets the levels to level,
+// removing the level of every component.
+func (l *Levels) NotifySignals(ctx context.Context, level Level) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGUSR1, syscall.SIGHUP)
+
+	go func() {
+		defer signal.Stop(signals)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case s := <-signals:
+				if s == syscall.SIGHUP {
+					l.Reset(level)
+					continue
+				}
+
+				l.SetLevel(l.Level().next())
+			}
+		}
+	}()
+}
+
+// next returns the level after l, wrapping from the most verbose level to error. Fatal is never returned, as fatal log
+// entries are always written.
+func (l Level) next() Level {
+	if l >= Trace {
+		return Error
+	}
+
+	return l + 1
+}
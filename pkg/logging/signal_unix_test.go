@@ -0,0 +1,67 @@
+//go:build !windows
+
+package logging
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestLevels_NotifySignals(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	levels := &Levels{level: Info, components: map[string]Level{"people": Error}}
+	levels.NotifySignals(ctx, Info)
+
+	waitForLevel := func(want Level) {
+		deadline := time.Now().Add(time.Second)
+
+		for levels.Level() != want && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+
+		if got := levels.Level(); got != want {
+			t.Fatalf("NotifySignals() level = %v, want %v", got, want)
+		}
+	}
+
+	syscall.Kill(os.Getpid(), syscall.SIGUSR1) //nolint:errcheck
+	waitForLevel(Debug)
+
+	syscall.Kill(os.Getpid(), syscall.SIGUSR1) //nolint:errcheck
+	waitForLevel(Trace)
+
+	syscall.Kill(os.Getpid(), syscall.SIGUSR1) //nolint:errcheck
+	waitForLevel(Error)
+
+	syscall.Kill(os.Getpid(), syscall.SIGHUP) //nolint:errcheck
+	waitForLevel(Info)
+
+	if got := levels.Components(); len(got) != 0 {
+		t.Errorf("NotifySignals() components = %v, want none", got)
+	}
+}
+
+func TestLevel_next(t *testing.T) {
+	tests := []struct {
+		l    Level
+		want Level
+	}{
+		{Fatal, Error},
+		{Error, Warn},
+		{Warn, Info},
+		{Info, Debug},
+		{Debug, Trace},
+		{Trace, Error},
+	}
+
+	for _, tt := range tests {
+		if got := tt.l.next(); got != tt.want {
+			t.Errorf("next(%v) = %v, want %v", tt.l, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,9 @@
+//go:build windows
+
+package logging
+
+import "context"
+
+// NotifySignals does nothing, as Windows does not support SIGUSR1 and SIGHUP. The levels may still be changed while
+// they are in use.
+func (l *Levels) NotifySignals(ctx context.Context, level Level) {}
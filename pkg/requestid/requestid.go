@@ -0,0 +1,60 @@
+// Package requestid provides request IDs, which correlate the log entries and outbound requests made while handling an
+// HTTP request, and carries them through a context.
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+)
+
+// Header is the HTTP header in which request IDs are received, returned and forwarded.
+const Header = "X-Request-ID"
+
+// maxLength is the maximum length of a request ID accepted from a client.
+const maxLength = 128
+
+// idBytes is the number of random bytes in a generated request ID.
+const idBytes = 16
+
+type contextKey struct{}
+
+// New returns a random request ID of 32 hexadecimal characters.
+func New() (string, error) {
+	b := make([]byte, idBytes)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("requestid.New: failed to generate request ID: %w", err)
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// Valid reports whether the request ID received from a client may be used. Valid request IDs are between 1 and 128
+// printable ASCII characters, so they are safe to log and to forward in headers.
+func Valid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+
+	for i := 0; i < len(id); i++ {
+		if id[i] < '!' || id[i] > '~' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// NewContext returns a copy of ctx carrying the request ID.
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request ID carried by ctx, if any.
+func FromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(contextKey{}).(string)
+
+	return id, ok
+}
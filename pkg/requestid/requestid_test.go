@@ -0,0 +1,64 @@
+package requestid
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	first, err := New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	second, _ := New()
+
+	if len(first) != 32 || !Valid(first) {
+		t.Errorf("New() = %v, want 32 hexadecimal characters", first)
+	}
+
+	if first == second {
+		t.Errorf("New() = %v twice, want unique request IDs", first)
+	}
+}
+
+func TestValid(t *testing.T) {
+	tests := []struct {
+		name string
+		id   string
+		want bool
+	}{
+		{"Given a UUID then it is valid", "3f1c1f9e-8a3b-4c55-9d0c-0b6a1f0c9e2d", true},
+		{"Given an empty ID then it is invalid", "", false},
+		{"Given an ID with a space then it is invalid", "request 1", false},
+		{"Given an ID with a newline then it is invalid", "request\n1", false},
+		{"Given an ID with non ASCII characters then it is invalid", "requête", false},
+		{"Given an ID of 128 characters then it is valid", strings.Repeat("a", 128), true},
+		{"Given an ID of 129 characters then it is invalid", strings.Repeat("a", 129), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Valid(tt.id); got != tt.want {
+				t.Errorf("Valid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	t.Run("Given a context without a request ID then false is returned", func(t *testing.T) {
+		if id, ok := FromContext(context.Background()); ok {
+			t.Errorf("FromContext() = %v, %v, want false", id, ok)
+		}
+	})
+
+	t.Run("Given a context with a request ID then the request ID is returned", func(t *testing.T) {
+		ctx := NewContext(context.Background(), "test-request-id")
+
+		if id, ok := FromContext(ctx); !ok || id != "test-request-id" {
+			t.Errorf("FromContext() = %v, %v, want test-request-id, true", id, ok)
+		}
+	})
+}